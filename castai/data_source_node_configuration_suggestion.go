@@ -0,0 +1,197 @@
+package castai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+)
+
+const (
+	FieldNodeConfigurationSuggestionSubnetDetails = "subnet_details"
+)
+
+func dataSourceNodeConfigurationSuggestion() *schema.Resource {
+	nodeConfiguration := resourceNodeConfiguration().Schema
+	eks := nodeConfiguration[FieldNodeConfigurationEKS].Elem.(*schema.Resource).Schema
+	gke := nodeConfiguration[FieldNodeConfigurationGKE].Elem.(*schema.Resource).Schema
+	aks := nodeConfiguration[FieldNodeConfigurationAKS].Elem.(*schema.Resource).Schema
+
+	return &schema.Resource{
+		ReadContext: dataSourceNodeConfigurationSuggestionRead,
+		Description: "Retrieve node configuration settings suggested by CAST AI for a cluster. " +
+			"Attributes have the same shape as the ones of `castai_node_configuration` resource, so they can be passed to it directly.",
+		Schema: map[string]*schema.Schema{
+			FieldClusterID: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsUUID),
+				Description:      "CAST AI cluster id",
+			},
+			FieldNodeConfigurationSubnets: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Suggested subnet ids to be used for provisioned nodes",
+			},
+			FieldNodeConfigurationSuggestionSubnetDetails: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Subnet id",
+						},
+						"cidr": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "CIDR block of the subnet",
+						},
+						"zone": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Zone of the subnet",
+						},
+						"available_ip_address_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of available IP addresses in the subnet. Populated for EKS only",
+						},
+					},
+				},
+				Description: "Details of the suggested subnets",
+			},
+			FieldNodeConfigurationImage: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Image used by the default node configuration of the cluster. Empty when the image is resolved by CAST AI automatically",
+			},
+			FieldNodeConfigurationEKS: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: toComputedSchema(map[string]*schema.Schema{
+						"security_groups":      eks["security_groups"],
+						"instance_profile_arn": eks["instance_profile_arn"],
+						"dns_cluster_ip":       eks["dns_cluster_ip"],
+					}),
+				},
+				Description: "Suggested EKS specific settings. Populated for EKS clusters only",
+			},
+			FieldNodeConfigurationGKE: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: toComputedSchema(map[string]*schema.Schema{
+						"max_pods_per_node": gke["max_pods_per_node"],
+					}),
+				},
+				Description: "Suggested GKE specific settings. Populated for GKE clusters only",
+			},
+			FieldNodeConfigurationAKS: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: toComputedSchema(map[string]*schema.Schema{
+						"max_pods_per_node": aks["max_pods_per_node"],
+					}),
+				},
+				Description: "Suggested AKS specific settings. Populated for AKS clusters only",
+			},
+		},
+	}
+}
+
+func dataSourceNodeConfigurationSuggestionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderConfig).api
+	clusterID := d.Get(FieldClusterID).(string)
+
+	suggestion, err := client.NodeConfigurationAPIGetSuggestedConfigurationWithResponse(ctx, clusterID)
+	if checkErr := sdk.CheckOKResponse(suggestion, err); checkErr != nil {
+		return diag.FromErr(fmt.Errorf("retrieving suggested node configuration: %w", checkErr))
+	}
+
+	cluster, err := client.ExternalClusterAPIGetClusterWithResponse(ctx, clusterID)
+	if checkErr := sdk.CheckOKResponse(cluster, err); checkErr != nil {
+		return diag.FromErr(fmt.Errorf("retrieving cluster: %w", checkErr))
+	}
+
+	configurations, err := client.NodeConfigurationAPIListConfigurationsWithResponse(ctx, clusterID)
+	if checkErr := sdk.CheckOKResponse(configurations, err); checkErr != nil {
+		return diag.FromErr(fmt.Errorf("retrieving node configurations: %w", checkErr))
+	}
+
+	subnets := make([]string, 0)
+	subnetDetails := make([]map[string]interface{}, 0)
+	for _, s := range lo.FromPtr(suggestion.JSON200.Subnets) {
+		subnets = append(subnets, lo.FromPtr(s.Id))
+
+		details := map[string]interface{}{
+			"id":                         lo.FromPtr(s.Id),
+			"cidr":                       lo.FromPtr(s.Cidr),
+			"available_ip_address_count": lo.FromPtr(s.AvailableIpAddressCount),
+		}
+		if s.Zone != nil {
+			details["zone"] = lo.FromPtr(s.Zone.Name)
+		}
+		subnetDetails = append(subnetDetails, details)
+	}
+
+	var image string
+	if cfg, ok := lo.Find(lo.FromPtr(configurations.JSON200.Items), func(cfg sdk.NodeconfigV1NodeConfiguration) bool {
+		return lo.FromPtr(cfg.Default)
+	}); ok {
+		image = lo.FromPtr(cfg.Image)
+	}
+
+	var eks, gke, aks []map[string]interface{}
+	if params := cluster.JSON200.Eks; params != nil {
+		securityGroups := lo.Map(lo.FromPtr(suggestion.JSON200.SecurityGroups), func(sg sdk.NodeconfigV1SecurityGroup, _ int) string {
+			return lo.FromPtr(sg.Id)
+		})
+		eks = []map[string]interface{}{{
+			"security_groups":      securityGroups,
+			"instance_profile_arn": lo.FromPtr(params.InstanceProfileArn),
+			"dns_cluster_ip":       lo.FromPtr(params.DnsClusterIp),
+		}}
+	}
+	if params := cluster.JSON200.Gke; params != nil {
+		gke = []map[string]interface{}{{
+			"max_pods_per_node": lo.FromPtr(params.MaxPodsPerNode),
+		}}
+	}
+	if params := cluster.JSON200.Aks; params != nil {
+		aks = []map[string]interface{}{{
+			"max_pods_per_node": lo.FromPtr(params.MaxPodsPerNode),
+		}}
+	}
+
+	d.SetId(clusterID)
+	if err := d.Set(FieldNodeConfigurationSubnets, subnets); err != nil {
+		return diag.FromErr(fmt.Errorf("setting subnets: %w", err))
+	}
+	if err := d.Set(FieldNodeConfigurationSuggestionSubnetDetails, subnetDetails); err != nil {
+		return diag.FromErr(fmt.Errorf("setting subnet details: %w", err))
+	}
+	if err := d.Set(FieldNodeConfigurationImage, image); err != nil {
+		return diag.FromErr(fmt.Errorf("setting image: %w", err))
+	}
+	if err := d.Set(FieldNodeConfigurationEKS, eks); err != nil {
+		return diag.FromErr(fmt.Errorf("setting eks: %w", err))
+	}
+	if err := d.Set(FieldNodeConfigurationGKE, gke); err != nil {
+		return diag.FromErr(fmt.Errorf("setting gke: %w", err))
+	}
+	if err := d.Set(FieldNodeConfigurationAKS, aks); err != nil {
+		return diag.FromErr(fmt.Errorf("setting aks: %w", err))
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package castai
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+func TestNodeConfigurationSuggestionDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+
+	ctx := context.Background()
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterId := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	suggestionBody := io.NopCloser(bytes.NewReader([]byte(`
+		{
+		  "subnets": [
+			{
+			  "id": "subnet-0a1b2c3d",
+			  "cidr": "10.0.0.0/19",
+			  "availableIpAddressCount": 8100,
+			  "zone": {"id": "euc1-az2", "name": "eu-central-1a"}
+			},
+			{
+			  "id": "subnet-4e5f6a7b",
+			  "cidr": "10.0.32.0/19",
+			  "availableIpAddressCount": 7900,
+			  "zone": {"id": "euc1-az3", "name": "eu-central-1b"}
+			}
+		  ],
+		  "securityGroups": [
+			{"id": "sg-0123456789", "name": "eks-cluster-sg"}
+		  ]
+		}
+	`)))
+	clusterBody := io.NopCloser(bytes.NewReader([]byte(`
+		{
+		  "id": "b6bfc074-a267-400f-b8f1-db0850c369b1",
+		  "eks": {
+			"clusterName": "prod",
+			"region": "eu-central-1",
+			"instanceProfileArn": "arn:aws:iam::123456789012:instance-profile/castai-eks-instance",
+			"dnsClusterIp": "10.100.0.10"
+		  }
+		}
+	`)))
+	configurationsBody := io.NopCloser(bytes.NewReader([]byte(`
+		{
+		  "items": [
+			{"id": "7dc4f922-29c9-4377-889c-0c8c5fb8d497", "name": "gpu", "default": false, "image": "gpu-image"},
+			{"id": "c0e6d9ac-dbe6-4a8f-8a2e-92e7a7b9a1f5", "name": "default", "default": true, "image": "amazon-eks-node-1.27-v20230703"}
+		  ]
+		}
+	`)))
+
+	mockClient.EXPECT().
+		NodeConfigurationAPIGetSuggestedConfiguration(gomock.Any(), clusterId).
+		Return(&http.Response{StatusCode: 200, Body: suggestionBody, Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+	mockClient.EXPECT().
+		ExternalClusterAPIGetCluster(gomock.Any(), clusterId).
+		Return(&http.Response{StatusCode: 200, Body: clusterBody, Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+	mockClient.EXPECT().
+		NodeConfigurationAPIListConfigurations(gomock.Any(), clusterId).
+		Return(&http.Response{StatusCode: 200, Body: configurationsBody, Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterID: cty.StringVal(clusterId),
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+
+	resource := dataSourceNodeConfigurationSuggestion()
+	data := resource.Data(state)
+
+	result := resource.ReadContext(ctx, data, provider)
+	r.Nil(result)
+	r.False(result.HasError())
+
+	r.Equal(clusterId, data.Id())
+	r.Equal([]interface{}{"subnet-0a1b2c3d", "subnet-4e5f6a7b"}, data.Get("subnets"))
+	r.Equal("10.0.32.0/19", data.Get("subnet_details.1.cidr"))
+	r.Equal("eu-central-1b", data.Get("subnet_details.1.zone"))
+	r.Equal(7900, data.Get("subnet_details.1.available_ip_address_count"))
+	r.Equal("amazon-eks-node-1.27-v20230703", data.Get("image"))
+	r.Equal(1, data.Get("eks.#"))
+	r.Equal([]interface{}{"sg-0123456789"}, data.Get("eks.0.security_groups"))
+	r.Equal("arn:aws:iam::123456789012:instance-profile/castai-eks-instance", data.Get("eks.0.instance_profile_arn"))
+	r.Equal("10.100.0.10", data.Get("eks.0.dns_cluster_ip"))
+	r.Equal(0, data.Get("gke.#"))
+	r.Equal(0, data.Get("aks.#"))
+}
@@ -0,0 +1,168 @@
+package castai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+)
+
+const (
+	FieldNodeConfigurationsDefault            = "default"
+	FieldNodeConfigurationsVersion            = "version"
+	FieldNodeConfigurationsNodeConfigurations = "node_configurations"
+)
+
+func dataSourceNodeConfigurations() *schema.Resource {
+	nodeConfiguration := resourceNodeConfiguration().Schema
+
+	nodeConfigurationSchema := toComputedSchema(map[string]*schema.Schema{
+		FieldNodeConfigurationName:             nodeConfiguration[FieldNodeConfigurationName],
+		FieldNodeConfigurationDiskCpuRatio:     nodeConfiguration[FieldNodeConfigurationDiskCpuRatio],
+		FieldNodeConfigurationMinDiskSize:      nodeConfiguration[FieldNodeConfigurationMinDiskSize],
+		FieldNodeConfigurationSubnets:          nodeConfiguration[FieldNodeConfigurationSubnets],
+		FieldNodeConfigurationSSHPublicKey:     nodeConfiguration[FieldNodeConfigurationSSHPublicKey],
+		FieldNodeConfigurationImage:            nodeConfiguration[FieldNodeConfigurationImage],
+		FieldNodeConfigurationTags:             nodeConfiguration[FieldNodeConfigurationTags],
+		FieldNodeConfigurationInitScript:       nodeConfiguration[FieldNodeConfigurationInitScript],
+		FieldNodeConfigurationContainerRuntime: nodeConfiguration[FieldNodeConfigurationContainerRuntime],
+		FieldNodeConfigurationDockerConfig:     nodeConfiguration[FieldNodeConfigurationDockerConfig],
+		FieldNodeConfigurationKubeletConfig:    nodeConfiguration[FieldNodeConfigurationKubeletConfig],
+		FieldNodeConfigurationEKS:              nodeConfiguration[FieldNodeConfigurationEKS],
+		FieldNodeConfigurationKOPS:             nodeConfiguration[FieldNodeConfigurationKOPS],
+		FieldNodeConfigurationAKS:              nodeConfiguration[FieldNodeConfigurationAKS],
+		FieldNodeConfigurationGKE:              nodeConfiguration[FieldNodeConfigurationGKE],
+	})
+	nodeConfigurationSchema["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "ID of the node configuration",
+	}
+	nodeConfigurationSchema[FieldNodeConfigurationsDefault] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Whether the node configuration is the default one of the cluster",
+	}
+	nodeConfigurationSchema[FieldNodeConfigurationsVersion] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Version of the node configuration, increased on every update",
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceNodeConfigurationsRead,
+		Description: "Retrieve node configurations of a cluster, including the ones created by CAST AI during onboarding",
+		Schema: map[string]*schema.Schema{
+			FieldClusterID: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsUUID),
+				Description:      "CAST AI cluster id",
+			},
+			FieldNodeConfigurationName: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the node configuration with the given name",
+			},
+			FieldNodeConfigurationsDefault: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return the default node configuration when true, or non-default ones when false",
+			},
+			FieldNodeConfigurationsNodeConfigurations: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: nodeConfigurationSchema,
+				},
+				Description: "Node configurations matching the filters",
+			},
+		},
+	}
+}
+
+func dataSourceNodeConfigurationsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderConfig).api
+	clusterID := d.Get(FieldClusterID).(string)
+	name := d.Get(FieldNodeConfigurationName).(string)
+
+	var isDefault *bool
+	if config := d.GetRawConfig(); !config.IsNull() && config.Type().HasAttribute(FieldNodeConfigurationsDefault) {
+		if v := config.GetAttr(FieldNodeConfigurationsDefault); !v.IsNull() && v.IsKnown() {
+			isDefault = lo.ToPtr(v.True())
+		}
+	}
+
+	resp, err := client.NodeConfigurationAPIListConfigurationsWithResponse(ctx, clusterID)
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return diag.FromErr(fmt.Errorf("retrieving node configurations: %w", checkErr))
+	}
+
+	nodeConfigurations := make([]map[string]interface{}, 0)
+	for _, cfg := range lo.FromPtr(resp.JSON200.Items) {
+		if name != "" && lo.FromPtr(cfg.Name) != name {
+			continue
+		}
+		if isDefault != nil && lo.FromPtr(cfg.Default) != *isDefault {
+			continue
+		}
+
+		m, err := flattenNodeConfiguration(cfg)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("flattening node configuration %q: %w", lo.FromPtr(cfg.Name), err))
+		}
+		nodeConfigurations = append(nodeConfigurations, m)
+	}
+
+	d.SetId(clusterID)
+	if err := d.Set(FieldNodeConfigurationsNodeConfigurations, nodeConfigurations); err != nil {
+		return diag.FromErr(fmt.Errorf("setting node configurations: %w", err))
+	}
+
+	return nil
+}
+
+func flattenNodeConfiguration(cfg sdk.NodeconfigV1NodeConfiguration) (map[string]interface{}, error) {
+	m := map[string]interface{}{
+		"id":                                   lo.FromPtr(cfg.Id),
+		FieldNodeConfigurationName:             lo.FromPtr(cfg.Name),
+		FieldNodeConfigurationsDefault:         lo.FromPtr(cfg.Default),
+		FieldNodeConfigurationsVersion:         lo.FromPtr(cfg.Version),
+		FieldNodeConfigurationDiskCpuRatio:     lo.FromPtr(cfg.DiskCpuRatio),
+		FieldNodeConfigurationMinDiskSize:      lo.FromPtr(cfg.MinDiskSize),
+		FieldNodeConfigurationSubnets:          lo.FromPtr(cfg.Subnets),
+		FieldNodeConfigurationSSHPublicKey:     lo.FromPtr(cfg.SshPublicKey),
+		FieldNodeConfigurationImage:            lo.FromPtr(cfg.Image),
+		FieldNodeConfigurationInitScript:       lo.FromPtr(cfg.InitScript),
+		FieldNodeConfigurationContainerRuntime: string(lo.FromPtr(cfg.ContainerRuntime)),
+		FieldNodeConfigurationEKS:              flattenEKSConfig(cfg.Eks),
+		FieldNodeConfigurationKOPS:             flattenKOPSConfig(cfg.Kops),
+		FieldNodeConfigurationAKS:              flattenAKSConfig(cfg.Aks),
+		FieldNodeConfigurationGKE:              flattenGKEConfig(cfg.Gke),
+	}
+	if cfg.Tags != nil {
+		m[FieldNodeConfigurationTags] = cfg.Tags.AdditionalProperties
+	}
+	if v := cfg.DockerConfig; v != nil {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		m[FieldNodeConfigurationDockerConfig] = string(b)
+	}
+	if v := cfg.KubeletConfig; v != nil {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		m[FieldNodeConfigurationKubeletConfig] = string(b)
+	}
+
+	return m, nil
+}
@@ -0,0 +1,200 @@
+package castai
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+func TestNodeConfigurationsDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	clusterId := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	listBody := `
+		{
+		  "items": [
+			{
+			  "id": "c0e6d9ac-dbe6-4a8f-8a2e-92e7a7b9a1f5",
+			  "name": "default",
+			  "default": true,
+			  "version": 3,
+			  "diskCpuRatio": 0,
+			  "minDiskSize": 100,
+			  "subnets": ["subnet-0a1b2c3d", "subnet-4e5f6a7b"],
+			  "image": "amazon-eks-node-1.27-v20230703",
+			  "containerRuntime": "CONTAINERD",
+			  "kubeletConfig": {"registryBurst": 20},
+			  "tags": {"env": "production"},
+			  "eks": {
+				"instanceProfileArn": "arn:aws:iam::123456789012:instance-profile/castai-eks-instance",
+				"securityGroups": ["sg-0123456789"],
+				"dnsClusterIp": "10.100.0.10",
+				"imdsV1": true,
+				"imdsHopLimit": 2
+			  }
+			},
+			{
+			  "id": "7dc4f922-29c9-4377-889c-0c8c5fb8d497",
+			  "name": "gpu",
+			  "default": false,
+			  "version": 1,
+			  "minDiskSize": 200,
+			  "subnets": ["subnet-0a1b2c3d"],
+			  "tags": {}
+			}
+		  ]
+		}
+	`
+
+	tt := map[string]struct {
+		config   map[string]cty.Value
+		expected []string
+	}{
+		"all configurations": {
+			config:   map[string]cty.Value{},
+			expected: []string{"default", "gpu"},
+		},
+		"by name": {
+			config:   map[string]cty.Value{FieldNodeConfigurationName: cty.StringVal("gpu")},
+			expected: []string{"gpu"},
+		},
+		"by default flag": {
+			config:   map[string]cty.Value{FieldNodeConfigurationsDefault: cty.True},
+			expected: []string{"default"},
+		},
+		"by default flag set to false": {
+			config:   map[string]cty.Value{FieldNodeConfigurationsDefault: cty.False},
+			expected: []string{"gpu"},
+		},
+		"by name of non-default configuration": {
+			config: map[string]cty.Value{
+				FieldNodeConfigurationName:     cty.StringVal("gpu"),
+				FieldNodeConfigurationsDefault: cty.True,
+			},
+			expected: []string{},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := require.New(t)
+			mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+
+			ctx := context.Background()
+			provider := &ProviderConfig{
+				api: &sdk.ClientWithResponses{
+					ClientInterface: mockClient,
+				},
+			}
+
+			mockClient.EXPECT().
+				NodeConfigurationAPIListConfigurations(gomock.Any(), clusterId).
+				Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(listBody))), Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+
+			tc.config[FieldClusterID] = cty.StringVal(clusterId)
+			val := cty.ObjectVal(tc.config)
+			state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+			state.RawConfig = val
+
+			resource := dataSourceNodeConfigurations()
+			data := resource.Data(state)
+
+			result := resource.ReadContext(ctx, data, provider)
+			r.Nil(result)
+			r.Equal(clusterId, data.Id())
+
+			names := make([]string, 0)
+			for _, cfg := range data.Get(FieldNodeConfigurationsNodeConfigurations).([]interface{}) {
+				names = append(names, cfg.(map[string]interface{})[FieldNodeConfigurationName].(string))
+			}
+			r.Equal(tc.expected, names)
+		})
+	}
+}
+
+func TestNodeConfigurationsDataSourceRead_fields(t *testing.T) {
+	t.Parallel()
+
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+
+	ctx := context.Background()
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterId := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	body := io.NopCloser(bytes.NewReader([]byte(`
+		{
+		  "items": [
+			{
+			  "id": "c0e6d9ac-dbe6-4a8f-8a2e-92e7a7b9a1f5",
+			  "name": "default",
+			  "default": true,
+			  "version": 3,
+			  "diskCpuRatio": 5,
+			  "minDiskSize": 100,
+			  "subnets": ["subnet-0a1b2c3d", "subnet-4e5f6a7b"],
+			  "image": "amazon-eks-node-1.27-v20230703",
+			  "containerRuntime": "CONTAINERD",
+			  "kubeletConfig": {"registryBurst": 20},
+			  "tags": {"env": "production"},
+			  "eks": {
+				"instanceProfileArn": "arn:aws:iam::123456789012:instance-profile/castai-eks-instance",
+				"securityGroups": ["sg-0123456789"],
+				"dnsClusterIp": "10.100.0.10",
+				"imdsV1": true,
+				"imdsHopLimit": 2
+			  }
+			}
+		  ]
+		}
+	`)))
+	mockClient.EXPECT().
+		NodeConfigurationAPIListConfigurations(gomock.Any(), clusterId).
+		Return(&http.Response{StatusCode: 200, Body: body, Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterID:                 cty.StringVal(clusterId),
+		FieldNodeConfigurationsDefault: cty.True,
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+	state.RawConfig = val
+
+	resource := dataSourceNodeConfigurations()
+	data := resource.Data(state)
+
+	result := resource.ReadContext(ctx, data, provider)
+	r.Nil(result)
+
+	r.Equal(1, data.Get("node_configurations.#"))
+	r.Equal("c0e6d9ac-dbe6-4a8f-8a2e-92e7a7b9a1f5", data.Get("node_configurations.0.id"))
+	r.Equal(true, data.Get("node_configurations.0.default"))
+	r.Equal(3, data.Get("node_configurations.0.version"))
+	r.Equal(5, data.Get("node_configurations.0.disk_cpu_ratio"))
+	r.Equal(2, data.Get("node_configurations.0.subnets.#"))
+	r.Equal("amazon-eks-node-1.27-v20230703", data.Get("node_configurations.0.image"))
+	r.Equal("CONTAINERD", data.Get("node_configurations.0.container_runtime"))
+	r.Equal(`{"registryBurst":20}`, data.Get("node_configurations.0.kubelet_config"))
+	r.Equal("", data.Get("node_configurations.0.docker_config"))
+	r.Equal("production", data.Get("node_configurations.0.tags.env"))
+	r.Equal("arn:aws:iam::123456789012:instance-profile/castai-eks-instance", data.Get("node_configurations.0.eks.0.instance_profile_arn"))
+	r.Equal("sg-0123456789", data.Get("node_configurations.0.eks.0.security_groups.0"))
+	r.Equal(2, data.Get("node_configurations.0.eks.0.imds_hop_limit"))
+	r.Equal(0, data.Get("node_configurations.0.gke.#"))
+}
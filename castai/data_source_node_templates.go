@@ -0,0 +1,127 @@
+package castai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+)
+
+const (
+	FieldNodeTemplatesNamePrefix    = "name_prefix"
+	FieldNodeTemplatesNodeTemplates = "node_templates"
+)
+
+func dataSourceNodeTemplates() *schema.Resource {
+	nodeTemplate := resourceNodeTemplate().Schema
+
+	nodeTemplateSchema := toComputedSchema(map[string]*schema.Schema{
+		FieldNodeTemplateIsEnabled:       nodeTemplate[FieldNodeTemplateIsEnabled],
+		FieldNodeTemplateIsDefault:       nodeTemplate[FieldNodeTemplateIsDefault],
+		FieldNodeTemplateConfigurationId: nodeTemplate[FieldNodeTemplateConfigurationId],
+		FieldNodeTemplateConstraints:     nodeTemplate[FieldNodeTemplateConstraints],
+		FieldNodeTemplateCustomLabels:    nodeTemplate[FieldNodeTemplateCustomLabels],
+		FieldNodeTemplateCustomTaints:    nodeTemplate[FieldNodeTemplateCustomTaints],
+	})
+	nodeTemplateSchema[FieldNodeTemplateName] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Name of the node template.",
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceNodeTemplatesRead,
+		Description: "Retrieve node templates of a cluster, including the ones not managed by Terraform",
+		Schema: map[string]*schema.Schema{
+			FieldClusterId: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsUUID),
+				Description:      "CAST AI cluster id.",
+			},
+			FieldNodeTemplatesNamePrefix: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return node templates which names start with the given prefix.",
+			},
+			FieldNodeTemplateIsEnabled: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return node templates which are enabled or disabled.",
+			},
+			FieldNodeTemplatesNodeTemplates: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: nodeTemplateSchema,
+				},
+				Description: "Node templates matching the filters.",
+			},
+		},
+	}
+}
+
+func dataSourceNodeTemplatesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ProviderConfig).api
+	clusterID := d.Get(FieldClusterId).(string)
+	namePrefix := d.Get(FieldNodeTemplatesNamePrefix).(string)
+
+	var isEnabled *bool
+	if config := d.GetRawConfig(); !config.IsNull() && config.Type().HasAttribute(FieldNodeTemplateIsEnabled) {
+		if v := config.GetAttr(FieldNodeTemplateIsEnabled); !v.IsNull() && v.IsKnown() {
+			isEnabled = lo.ToPtr(v.True())
+		}
+	}
+
+	resp, err := client.NodeTemplatesAPIListNodeTemplatesWithResponse(ctx, clusterID, &sdk.NodeTemplatesAPIListNodeTemplatesParams{IncludeDefault: lo.ToPtr(true)})
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return diag.FromErr(fmt.Errorf("retrieving node templates: %w", checkErr))
+	}
+
+	nodeTemplates := make([]map[string]any, 0)
+	for _, item := range lo.FromPtr(resp.JSON200.Items) {
+		t := item.Template
+		if t == nil {
+			continue
+		}
+		if !strings.HasPrefix(lo.FromPtr(t.Name), namePrefix) {
+			continue
+		}
+		if isEnabled != nil && lo.FromPtr(t.IsEnabled) != *isEnabled {
+			continue
+		}
+
+		constraints, err := flattenConstraints(t.Constraints)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("flattening constraints: %w", err))
+		}
+
+		var customLabels map[string]string
+		if t.CustomLabels != nil {
+			customLabels = t.CustomLabels.AdditionalProperties
+		}
+
+		nodeTemplates = append(nodeTemplates, map[string]any{
+			FieldNodeTemplateName:            lo.FromPtr(t.Name),
+			FieldNodeTemplateIsEnabled:       lo.FromPtr(t.IsEnabled),
+			FieldNodeTemplateIsDefault:       lo.FromPtr(t.IsDefault),
+			FieldNodeTemplateConfigurationId: lo.FromPtr(t.ConfigurationId),
+			FieldNodeTemplateConstraints:     constraints,
+			FieldNodeTemplateCustomLabels:    customLabels,
+			FieldNodeTemplateCustomTaints:    flattenCustomTaints(t.CustomTaints),
+		})
+	}
+
+	d.SetId(clusterID)
+	if err := d.Set(FieldNodeTemplatesNodeTemplates, nodeTemplates); err != nil {
+		return diag.FromErr(fmt.Errorf("setting node templates: %w", err))
+	}
+
+	return nil
+}
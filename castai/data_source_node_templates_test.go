@@ -0,0 +1,111 @@
+package castai
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+func TestNodeTemplatesDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+
+	ctx := context.Background()
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterId := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	body := io.NopCloser(bytes.NewReader([]byte(`
+		{
+		  "items": [
+			{
+			  "template": {
+				"configurationId": "7dc4f922-29c9-4377-889c-0c8c5fb8d497",
+				"name": "default-by-castai",
+				"isEnabled": true,
+				"isDefault": true
+			  }
+			},
+			{
+			  "template": {
+				"configurationId": "7dc4f922-29c9-4377-889c-0c8c5fb8d497",
+				"name": "team-a-gpu",
+				"isEnabled": true,
+				"isDefault": false,
+				"constraints": {
+				  "spot": true,
+				  "onDemand": false,
+				  "minCpu": 4,
+				  "gpu": {
+					"manufacturers": ["NVIDIA"]
+				  }
+				},
+				"customLabels": {
+				  "team": "a"
+				},
+				"customTaints": [
+				  {
+					"key": "gpu",
+					"value": "true",
+					"effect": "NoSchedule"
+				  }
+				]
+			  }
+			},
+			{
+			  "template": {
+				"name": "team-a-disabled",
+				"isEnabled": false
+			  }
+			}
+		  ]
+		}
+	`)))
+	mockClient.EXPECT().
+		NodeTemplatesAPIListNodeTemplates(gomock.Any(), clusterId, &sdk.NodeTemplatesAPIListNodeTemplatesParams{IncludeDefault: lo.ToPtr(true)}).
+		Return(&http.Response{StatusCode: 200, Body: body, Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterId:               cty.StringVal(clusterId),
+		FieldNodeTemplatesNamePrefix: cty.StringVal("team-a-"),
+		FieldNodeTemplateIsEnabled:   cty.True,
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+	state.RawConfig = val
+
+	resource := dataSourceNodeTemplates()
+	data := resource.Data(state)
+
+	result := resource.ReadContext(ctx, data, provider)
+	r.Nil(result)
+	r.False(result.HasError())
+
+	r.Equal(clusterId, data.Id())
+	r.Equal(1, data.Get("node_templates.#"))
+	r.Equal("team-a-gpu", data.Get("node_templates.0.name"))
+	r.Equal(true, data.Get("node_templates.0.is_enabled"))
+	r.Equal(false, data.Get("node_templates.0.is_default"))
+	r.Equal("7dc4f922-29c9-4377-889c-0c8c5fb8d497", data.Get("node_templates.0.configuration_id"))
+	r.Equal(true, data.Get("node_templates.0.constraints.0.spot"))
+	r.Equal(4, data.Get("node_templates.0.constraints.0.min_cpu"))
+	r.Equal("NVIDIA", data.Get("node_templates.0.constraints.0.gpu.0.manufacturers.0"))
+	r.Equal("a", data.Get("node_templates.0.custom_labels.team"))
+	r.Equal("gpu", data.Get("node_templates.0.custom_taints.0.key"))
+	r.Equal("NoSchedule", data.Get("node_templates.0.custom_taints.0.effect"))
+}
@@ -0,0 +1,73 @@
+package castai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+)
+
+const (
+	FieldRebalancingJobs = "jobs"
+)
+
+func dataSourceRebalancingJobs() *schema.Resource {
+	// Jobs are listed with the same attributes as castai_rebalancing_job resource has, all of them read-only.
+	job := toComputedSchema(resourceRebalancingJob().Schema)
+	delete(job, FieldClusterId)
+	job["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Rebalancing job id",
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceRebalancingJobsRead,
+		Description: "Retrieve all rebalancing jobs of a cluster.",
+		Schema: map[string]*schema.Schema{
+			FieldClusterID: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsUUID),
+				Description:      "CAST AI cluster id",
+			},
+			FieldRebalancingJobs: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: job,
+				},
+				Description: "Rebalancing jobs of the cluster",
+			},
+		},
+	}
+}
+
+func dataSourceRebalancingJobsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderConfig).api
+	clusterID := d.Get(FieldClusterID).(string)
+
+	resp, err := client.ScheduledRebalancingAPIListRebalancingJobsWithResponse(ctx, clusterID)
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return diag.FromErr(fmt.Errorf("listing rebalancing jobs: %w", checkErr))
+	}
+
+	jobs := lo.Map(lo.FromPtr(resp.JSON200.Jobs), func(job sdk.ScheduledrebalancingV1RebalancingJob, _ int) map[string]any {
+		result := rebalancingJobStatusToState(&job)
+		result["id"] = lo.FromPtr(job.Id)
+		result["rebalancing_schedule_id"] = lo.FromPtr(job.RebalancingScheduleId)
+		result["enabled"] = lo.FromPtr(job.Enabled)
+		return result
+	})
+
+	d.SetId(clusterID)
+	if err := d.Set(FieldRebalancingJobs, jobs); err != nil {
+		return diag.FromErr(fmt.Errorf("setting rebalancing jobs: %w", err))
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package castai
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+func TestRebalancingJobsDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+
+	ctx := context.Background()
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterId := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	body := io.NopCloser(bytes.NewReader([]byte(`
+		{
+		  "jobs": [
+			{
+			  "id": "2a6c0f4e-3b1d-4c8e-9f7a-5d2e1b0c9a87",
+			  "clusterId": "b6bfc074-a267-400f-b8f1-db0850c369b1",
+			  "rebalancingScheduleId": "9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c",
+			  "enabled": true,
+			  "lastTriggerAt": "2024-03-08T12:00:00Z",
+			  "nextTriggerAt": "2024-03-09T12:00:00Z",
+			  "status": "JobStatusFailed",
+			  "rebalancingPlanId": "c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f"
+			},
+			{
+			  "id": "7e8f9a0b-1c2d-4e3f-a4b5-c6d7e8f9a0b1",
+			  "clusterId": "b6bfc074-a267-400f-b8f1-db0850c369b1",
+			  "rebalancingScheduleId": "0d1e2f3a-4b5c-4d6e-8f7a-9b0c1d2e3f4a",
+			  "enabled": false,
+			  "lastTriggerAt": null,
+			  "nextTriggerAt": null
+			}
+		  ]
+		}
+	`)))
+	mockClient.EXPECT().
+		ScheduledRebalancingAPIListRebalancingJobs(gomock.Any(), clusterId).
+		Return(&http.Response{StatusCode: 200, Body: body, Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+
+	resource := dataSourceRebalancingJobs()
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterID: cty.StringVal(clusterId),
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+	data := resource.Data(state)
+
+	result := resource.ReadContext(ctx, data, provider)
+	r.Nil(result)
+
+	r.Equal(clusterId, data.Id())
+	r.Equal(2, data.Get("jobs.#"))
+	r.Equal("2a6c0f4e-3b1d-4c8e-9f7a-5d2e1b0c9a87", data.Get("jobs.0.id"))
+	r.Equal(true, data.Get("jobs.0.enabled"))
+	r.Equal("2024-03-08T12:00:00Z", data.Get("jobs.0.last_trigger_at"))
+	r.Equal("JobStatusFailed", data.Get("jobs.0.status"))
+	r.Equal("c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f", data.Get("jobs.0.rebalancing_plan_id"))
+	r.Equal("0d1e2f3a-4b5c-4d6e-8f7a-9b0c1d2e3f4a", data.Get("jobs.1.rebalancing_schedule_id"))
+	r.Equal(false, data.Get("jobs.1.enabled"))
+	r.Equal("", data.Get("jobs.1.next_trigger_at"))
+}
@@ -0,0 +1,205 @@
+package castai
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+)
+
+const (
+	FieldRebalancingSchedulePreviewFrom              = "from"
+	FieldRebalancingSchedulePreviewTriggerTimesCount = "trigger_times_count"
+	FieldRebalancingSchedulePreviewTimeZone          = "time_zone"
+	FieldRebalancingSchedulePreviewTriggerTimes      = "trigger_times"
+	FieldRebalancingSchedulePreviewAffectedNodes     = "affected_nodes"
+)
+
+func dataSourceRebalancingSchedulePreview() *schema.Resource {
+	rebalancingSchedule := resourceRebalancingSchedule().Schema
+
+	launchConfiguration := rebalancingSchedule["launch_configuration"]
+	launchConfiguration.Required = false
+	launchConfiguration.Optional = true
+	launchConfiguration.Description = "Launch configuration used to preview the affected nodes. Same as the one of `castai_rebalancing_schedule` resource."
+
+	triggerConditions := rebalancingSchedule["trigger_conditions"]
+	triggerConditions.Required = false
+	triggerConditions.Optional = true
+	triggerConditions.Description = "Trigger conditions used to preview the affected nodes. Same as the ones of `castai_rebalancing_schedule` resource."
+
+	return &schema.Resource{
+		ReadContext: dataSourceRebalancingSchedulePreviewRead,
+		Description: "Preview when a rebalancing schedule triggers and, when cluster is provided, which nodes it would target. " +
+			"Takes the same schedule and launch configuration as `castai_rebalancing_schedule` resource.",
+		Schema: map[string]*schema.Schema{
+			FieldClusterID: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsUUID),
+				Description:      "CAST AI cluster id. When provided, trigger times and nodes affected by the schedule are previewed by CAST AI",
+			},
+			"schedule":             rebalancingSchedule["schedule"],
+			"launch_configuration": launchConfiguration,
+			"trigger_conditions":   triggerConditions,
+			FieldRebalancingSchedulePreviewFrom: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{FieldClusterID},
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+				Description:      "Time in RFC 3339 format to preview trigger times from. Defaults to current time. Can't be used with `cluster_id`, CAST AI previews trigger times from current time",
+			},
+			FieldRebalancingSchedulePreviewTriggerTimesCount: {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          5,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 100)),
+				Description:      "Number of upcoming trigger times to preview. Defaults to 5",
+			},
+			FieldRebalancingSchedulePreviewTimeZone: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time zone the cron expression is interpreted in. Taken from `CRON_TZ` of the expression, UTC otherwise",
+			},
+			FieldRebalancingSchedulePreviewTriggerTimes: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"utc": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Trigger time in UTC, in RFC 3339 format",
+						},
+						"local": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Trigger time in the time zone of the schedule, in RFC 3339 format",
+						},
+					},
+				},
+				Description: "Upcoming trigger times of the schedule",
+			},
+			FieldRebalancingSchedulePreviewAffectedNodes: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Node id",
+						},
+					},
+				},
+				Description: "Nodes that would be targeted by the schedule if it triggered now. Populated only when `cluster_id` is provided",
+			},
+		},
+	}
+}
+
+func dataSourceRebalancingSchedulePreviewRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	scheduleData := toSection(d, "schedule")
+	cron, err := scheduleCron(scheduleData)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	schedule, err := parseCron(cron)
+	if err != nil {
+		return diag.Errorf("parsing cron expression %q: %v", cron, err)
+	}
+	if err := validateRebalancingTimeZone(ctx, meta.(*ProviderConfig).api, cron); err != nil {
+		return diag.FromErr(err)
+	}
+
+	from := time.Now()
+	if v, ok := d.GetOk(FieldRebalancingSchedulePreviewFrom); ok {
+		if from, err = time.Parse(time.RFC3339, v.(string)); err != nil {
+			return diag.FromErr(fmt.Errorf("parsing %s: %w", FieldRebalancingSchedulePreviewFrom, err))
+		}
+	}
+	count := d.Get(FieldRebalancingSchedulePreviewTriggerTimesCount).(int)
+
+	var next []time.Time
+	clusterID := d.Get(FieldClusterID).(string)
+	if clusterID != "" {
+		preview, err := previewRebalancingSchedule(ctx, d, meta, clusterID, cron)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		next = lo.FromPtr(preview.WillTriggerAt)
+		affectedNodes := lo.Map(lo.FromPtr(preview.AffectedNodes), func(node sdk.ScheduledrebalancingV1Node, _ int) map[string]any {
+			return map[string]any{
+				"id": lo.FromPtr(node.Id),
+			}
+		})
+		if err := d.Set(FieldRebalancingSchedulePreviewAffectedNodes, affectedNodes); err != nil {
+			return diag.FromErr(fmt.Errorf("setting affected nodes: %w", err))
+		}
+	} else {
+		next = cronNextN(schedule, from, count)
+	}
+
+	if at, _ := scheduleData[FieldRebalancingScheduleAt].(string); at != "" {
+		// One-shot schedule triggers only once, jobs are disabled afterwards.
+		next = nil
+		if t, err := time.Parse(time.RFC3339, at); err == nil && t.After(from) {
+			next = []time.Time{t.Truncate(time.Minute)}
+		}
+	}
+	if len(next) > count {
+		next = next[:count]
+	}
+	triggerTimes := lo.Map(next, func(t time.Time, _ int) map[string]any {
+		return map[string]any{
+			"utc":   t.UTC().Format(time.RFC3339),
+			"local": t.In(schedule.Location).Format(time.RFC3339),
+		}
+	})
+	if err := d.Set(FieldRebalancingSchedulePreviewTimeZone, schedule.Location.String()); err != nil {
+		return diag.FromErr(fmt.Errorf("setting time zone: %w", err))
+	}
+	if err := d.Set(FieldRebalancingSchedulePreviewTriggerTimes, triggerTimes); err != nil {
+		return diag.FromErr(fmt.Errorf("setting trigger times: %w", err))
+	}
+
+	if clusterID == "" {
+		d.SetId(cron)
+		return nil
+	}
+	d.SetId(clusterID + "/" + cron)
+	return nil
+}
+
+func previewRebalancingSchedule(ctx context.Context, d *schema.ResourceData, meta interface{}, clusterID, cron string) (*sdk.ScheduledrebalancingV1PreviewRebalancingScheduleResponse, error) {
+	req := sdk.ScheduledRebalancingAPIPreviewRebalancingScheduleJSONRequestBody{
+		Schedule: &sdk.ScheduledrebalancingV1Schedule{
+			Cron: cron,
+		},
+	}
+	if launchConfigurationData := toSection(d, "launch_configuration"); launchConfigurationData != nil {
+		launchConfiguration, err := toLaunchConfiguration(launchConfigurationData)
+		if err != nil {
+			return nil, err
+		}
+		req.LaunchConfiguration = launchConfiguration
+	}
+	if triggerConditions := toSection(d, "trigger_conditions"); triggerConditions != nil {
+		req.TriggerConditions = &sdk.ScheduledrebalancingV1TriggerConditions{
+			SavingsPercentage: readOptionalNumber[float64, float32](triggerConditions, "savings_percentage"),
+		}
+	}
+
+	resp, err := meta.(*ProviderConfig).api.ScheduledRebalancingAPIPreviewRebalancingScheduleWithResponse(ctx, clusterID, req)
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return nil, fmt.Errorf("previewing rebalancing schedule: %w", checkErr)
+	}
+	return resp.JSON200, nil
+}
@@ -0,0 +1,153 @@
+package castai
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+func TestRebalancingSchedulePreviewDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+
+	ctx := context.Background()
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterId := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	previewBody := io.NopCloser(bytes.NewReader([]byte(`
+		{
+		  "affectedNodes": [
+			{"id": "2e9a8b1c-7f3d-4c55-9a61-0d2b3c4e5f60"}
+		  ],
+		  "willTriggerAt": [
+			"2024-03-08T17:00:00Z",
+			"2024-03-09T17:00:00Z",
+			"2024-03-10T16:00:00Z",
+			"2024-03-11T16:00:00Z"
+		  ]
+		}
+	`)))
+
+	mockClient.EXPECT().
+		ScheduledRebalancingAPIListAvailableRebalancingTZ(gomock.Any()).
+		Return(&http.Response{StatusCode: 200, Body: timeZonesBody(), Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+	mockClient.EXPECT().
+		ScheduledRebalancingAPIPreviewRebalancingSchedule(gomock.Any(), clusterId, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, req sdk.ScheduledRebalancingAPIPreviewRebalancingScheduleJSONRequestBody) (*http.Response, error) {
+			r.Equal("CRON_TZ=America/New_York 0 12 * * ?", req.Schedule.Cron)
+			r.Equal(int32(2), *req.LaunchConfiguration.NumTargetedNodes)
+			return &http.Response{StatusCode: 200, Body: previewBody, Header: map[string][]string{"Content-Type": {"json"}}}, nil
+		})
+
+	resource := dataSourceRebalancingSchedulePreview()
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterID: cty.StringVal(clusterId),
+		"schedule": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"cron": cty.StringVal("CRON_TZ=America/New_York 0 12 * * ?"),
+		})}),
+		"launch_configuration": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"num_targeted_nodes": cty.NumberIntVal(2),
+		})}),
+		FieldRebalancingSchedulePreviewTriggerTimesCount: cty.NumberIntVal(3),
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+	data := resource.Data(state)
+
+	result := resource.ReadContext(ctx, data, provider)
+	r.Nil(result)
+
+	r.Equal("America/New_York", data.Get(FieldRebalancingSchedulePreviewTimeZone))
+	r.Equal(3, data.Get("trigger_times.#"))
+	r.Equal("2024-03-08T17:00:00Z", data.Get("trigger_times.0.utc"))
+	r.Equal("2024-03-08T12:00:00-05:00", data.Get("trigger_times.0.local"))
+	r.Equal("2024-03-10T16:00:00Z", data.Get("trigger_times.2.utc"))
+	r.Equal("2024-03-10T12:00:00-04:00", data.Get("trigger_times.2.local"))
+	r.Equal(1, data.Get("affected_nodes.#"))
+	r.Equal("2e9a8b1c-7f3d-4c55-9a61-0d2b3c4e5f60", data.Get("affected_nodes.0.id"))
+}
+
+func TestRebalancingSchedulePreviewDataSourceRead_withoutCluster(t *testing.T) {
+	t.Parallel()
+
+	r := require.New(t)
+
+	resource := dataSourceRebalancingSchedulePreview()
+	val := cty.ObjectVal(map[string]cty.Value{
+		"schedule": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"cron": cty.StringVal("0 12 * * ?"),
+		})}),
+		FieldRebalancingSchedulePreviewFrom:              cty.StringVal("2024-03-08T10:30:00Z"),
+		FieldRebalancingSchedulePreviewTriggerTimesCount: cty.NumberIntVal(5),
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+	data := resource.Data(state)
+
+	result := resource.ReadContext(context.Background(), data, &ProviderConfig{})
+	r.Nil(result)
+
+	r.Equal("0 12 * * ?", data.Id())
+	r.Equal("UTC", data.Get(FieldRebalancingSchedulePreviewTimeZone))
+	r.Equal(5, data.Get("trigger_times.#"))
+	r.Equal("2024-03-12T12:00:00Z", data.Get("trigger_times.4.utc"))
+	r.Equal(0, data.Get("affected_nodes.#"))
+}
+
+func TestRebalancingSchedulePreviewDataSourceRead_oneShot(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		at       string
+		expected []string
+	}{
+		"upcoming": {
+			at:       "2024-12-01T02:00:00Z",
+			expected: []string{"2024-12-01T02:00:00Z"},
+		},
+		"passed": {
+			at:       "2024-03-01T02:00:00Z",
+			expected: []string{},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+
+			resource := dataSourceRebalancingSchedulePreview()
+			val := cty.ObjectVal(map[string]cty.Value{
+				"schedule": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					FieldRebalancingScheduleAt: cty.StringVal(tc.at),
+				})}),
+				FieldRebalancingSchedulePreviewFrom:              cty.StringVal("2024-03-08T10:30:00Z"),
+				FieldRebalancingSchedulePreviewTriggerTimesCount: cty.NumberIntVal(5),
+			})
+			data := resource.Data(terraform.NewInstanceStateShimmedFromValue(val, 0))
+
+			result := resource.ReadContext(context.Background(), data, &ProviderConfig{})
+			r.Nil(result)
+
+			actual := make([]string, 0)
+			for _, t := range data.Get(FieldRebalancingSchedulePreviewTriggerTimes).([]interface{}) {
+				actual = append(actual, t.(map[string]interface{})["utc"].(string))
+			}
+			r.Equal(tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package castai
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/samber/lo"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+)
+
+const (
+	FieldRebalancingTimeZones = "time_zones"
+)
+
+func dataSourceRebalancingTimeZones() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRebalancingTimeZonesRead,
+		Description: "Retrieve time zones supported in `CRON_TZ` of rebalancing schedule cron expressions.",
+		Schema: map[string]*schema.Schema{
+			FieldRebalancingTimeZones: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time zone name to be used in `CRON_TZ`, e.g. America/New_York",
+						},
+						"offset": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "UTC offset of the time zone",
+						},
+					},
+				},
+				Description: "Supported time zones",
+			},
+		},
+	}
+}
+
+func dataSourceRebalancingTimeZonesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	timeZones, err := listRebalancingTimeZones(ctx, meta.(*ProviderConfig).api)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("rebalancing-time-zones")
+	if err := d.Set(FieldRebalancingTimeZones, lo.Map(timeZones, func(tz sdk.ScheduledrebalancingV1TimeZone, _ int) map[string]any {
+		return map[string]any{
+			"name":   lo.FromPtr(tz.Name),
+			"offset": lo.FromPtr(tz.Offset),
+		}
+	})); err != nil {
+		return diag.FromErr(fmt.Errorf("setting time zones: %w", err))
+	}
+	return nil
+}
+
+func listRebalancingTimeZones(ctx context.Context, client *sdk.ClientWithResponses) ([]sdk.ScheduledrebalancingV1TimeZone, error) {
+	resp, err := client.ScheduledRebalancingAPIListAvailableRebalancingTZWithResponse(ctx)
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return nil, fmt.Errorf("listing rebalancing time zones: %w", checkErr)
+	}
+	return lo.FromPtr(resp.JSON200.TimeZones), nil
+}
+
+// validateRebalancingTimeZone checks that CRON_TZ of the cron expression is one of the time zones supported by rebalancing schedules.
+func validateRebalancingTimeZone(ctx context.Context, client *sdk.ClientWithResponses, cron string) error {
+	schedule, err := parseCron(cron)
+	if err != nil {
+		return fmt.Errorf("parsing cron expression %q: %w", cron, err)
+	}
+	if schedule.Location == time.UTC {
+		return nil
+	}
+
+	timeZones, err := listRebalancingTimeZones(ctx, client)
+	if err != nil {
+		return err
+	}
+	name := schedule.Location.String()
+	if !lo.ContainsBy(timeZones, func(tz sdk.ScheduledrebalancingV1TimeZone) bool { return lo.FromPtr(tz.Name) == name }) {
+		return fmt.Errorf("time zone %q of cron expression %q is not supported by rebalancing schedules, "+
+			"use one of the time zones listed by castai_rebalancing_time_zones data source", name, cron)
+	}
+	return nil
+}
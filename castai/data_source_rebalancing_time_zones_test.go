@@ -0,0 +1,52 @@
+package castai
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+func TestRebalancingTimeZonesDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	mockClient.EXPECT().
+		ScheduledRebalancingAPIListAvailableRebalancingTZ(gomock.Any()).
+		Return(&http.Response{StatusCode: 200, Body: timeZonesBody(), Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+
+	resource := dataSourceRebalancingTimeZones()
+	state := terraform.NewInstanceStateShimmedFromValue(cty.ObjectVal(map[string]cty.Value{}), 0)
+	data := resource.Data(state)
+
+	result := resource.ReadContext(context.Background(), data, provider)
+	r.Nil(result)
+	r.Equal(2, data.Get("time_zones.#"))
+	r.Equal("America/New_York", data.Get("time_zones.0.name"))
+	r.Equal("-05:00", data.Get("time_zones.0.offset"))
+}
+
+func timeZonesBody() io.ReadCloser {
+	return io.NopCloser(bytes.NewReader([]byte(`{
+	  "timeZones": [
+		{"name": "America/New_York", "offset": "-05:00"},
+		{"name": "Europe/Vilnius", "offset": "+02:00"}
+	  ]
+	}`)))
+}
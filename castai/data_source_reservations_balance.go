@@ -0,0 +1,240 @@
+package castai
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+
+	"github.com/castai/terraform-provider-castai/castai/reservations"
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+)
+
+const (
+	FieldReservationsBalanceReservationId  = "reservation_id"
+	FieldReservationsBalanceUsage          = "usage"
+	FieldReservationsBalanceUsedCount      = "used_count"
+	FieldReservationsBalanceRemainingCount = "remaining_count"
+	FieldReservationsBalanceInstanceTypes  = "instance_types"
+
+	FieldCountableInstanceTypeClusterId    = "cluster_id"
+	FieldCountableInstanceTypeInstanceType = "instance_type"
+	FieldCountableInstanceTypeProvider     = "provider"
+	FieldCountableInstanceTypeRegion       = "region"
+	FieldCountableInstanceTypeCount        = "count"
+	FieldCountableInstanceTypeVcpu         = "vcpu"
+	FieldCountableInstanceTypeRamMib       = "ram_mib"
+)
+
+func dataSourceReservationsBalance() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceReservationsBalanceRead,
+		Description: "Retrieve utilization of reservations of an organization, e.g. to check for under-utilized or expiring reservations.",
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(2 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			reservations.FieldReservationsOrganizationId: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsUUID),
+				Description:      "organization, defaults to the only organization of the user",
+			},
+			reservations.FieldReservations: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						FieldReservationsBalanceReservationId: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "reservation id",
+						},
+						reservations.FieldReservationName: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "unique reservation name in region for specific instance type",
+						},
+						reservations.FieldReservationProvider: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "reservation cloud provider (gcp, aws, azure)",
+						},
+						reservations.FieldReservationRegion: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "reservation region",
+						},
+						reservations.FieldReservationInstanceType: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "reserved instance type",
+						},
+						reservations.FieldReservationCount: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "amount of reserved instances",
+						},
+						reservations.FieldReservationStartDate: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "start date of reservation, in RFC 3339 format",
+						},
+						reservations.FieldReservationEndDate: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "end date of reservation, in RFC 3339 format, empty when reservation does not expire",
+						},
+						FieldReservationsBalanceUsage: {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "utilization of the reservation as reported by CAST AI",
+						},
+						FieldReservationsBalanceUsedCount: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "amount of instances using the reservation",
+						},
+						FieldReservationsBalanceRemainingCount: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "amount of reserved instances not used",
+						},
+						FieldReservationsBalanceInstanceTypes: {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Resource{Schema: countableInstanceTypeFields()},
+							Description: "instances using the reservation by cluster",
+						},
+					},
+				},
+				Description: "reservations with their utilization",
+			},
+		},
+	}
+}
+
+func dataSourceReservationsBalanceRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ProviderConfig).api
+
+	organizationId, err := getOrganizationId(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := client.InventoryAPIGetReservationsBalanceWithResponse(ctx, organizationId)
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return diag.FromErr(fmt.Errorf("fetching reservations balance: %w", checkErr))
+	}
+
+	balances := lo.Map(lo.FromPtr(resp.JSON200.Reservations), func(balance sdk.CastaiInventoryV1beta1ReservationBalance, _ int) map[string]any {
+		return reservationBalanceToState(balance)
+	})
+
+	d.SetId(organizationId)
+	if err := d.Set(reservations.FieldReservationsOrganizationId, organizationId); err != nil {
+		return diag.FromErr(fmt.Errorf("setting organization id: %w", err))
+	}
+	if err := d.Set(reservations.FieldReservations, balances); err != nil {
+		return diag.FromErr(fmt.Errorf("setting reservations: %w", err))
+	}
+
+	return nil
+}
+
+func reservationBalanceToState(balance sdk.CastaiInventoryV1beta1ReservationBalance) map[string]any {
+	reservation := lo.FromPtr(balance.Reservation)
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	instanceTypes := lo.FromPtr(balance.InstanceTypes)
+	count := int(lo.FromPtr(reservation.Count))
+	usedCount := lo.SumBy(instanceTypes, func(item sdk.CastaiInventoryV1beta1CountableInstanceType) int {
+		return int(lo.FromPtr(item.Count))
+	})
+
+	return map[string]any{
+		FieldReservationsBalanceReservationId:     lo.FromPtr(reservation.ReservationId),
+		reservations.FieldReservationName:         lo.FromPtr(reservation.Name),
+		reservations.FieldReservationProvider:     lo.FromPtr(reservation.Provider),
+		reservations.FieldReservationRegion:       lo.FromPtr(reservation.Region),
+		reservations.FieldReservationInstanceType: lo.FromPtr(reservation.InstanceType),
+		reservations.FieldReservationCount:        count,
+		reservations.FieldReservationStartDate:    formatTime(reservation.StartDate),
+		reservations.FieldReservationEndDate:      formatTime(reservation.EndDate),
+		FieldReservationsBalanceUsage:             lo.FromPtr(balance.Usage),
+		FieldReservationsBalanceUsedCount:         usedCount,
+		FieldReservationsBalanceRemainingCount:    lo.Max([]int{count - usedCount, 0}),
+		FieldReservationsBalanceInstanceTypes:     lo.Map(instanceTypes, countableInstanceTypeToState),
+	}
+}
+
+// countableInstanceTypeFields are attributes of instances counted by instance type and cluster,
+// shared by reservations balance and resource usage.
+func countableInstanceTypeFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		FieldCountableInstanceTypeClusterId: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "CAST AI cluster id",
+		},
+		FieldCountableInstanceTypeInstanceType: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "instance type",
+		},
+		FieldCountableInstanceTypeProvider: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "cloud provider of the instance type",
+		},
+		FieldCountableInstanceTypeRegion: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "region of the instance type",
+		},
+		FieldCountableInstanceTypeCount: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "amount of instances",
+		},
+		FieldCountableInstanceTypeVcpu: {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "vCPUs of a single instance, fractional for shared core instance types",
+		},
+		FieldCountableInstanceTypeRamMib: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "memory of a single instance in MiB",
+		},
+	}
+}
+
+func countableInstanceTypeToState(item sdk.CastaiInventoryV1beta1CountableInstanceType, _ int) map[string]any {
+	instanceType := lo.FromPtr(item.InstanceType)
+	vcpu, _ := strconv.ParseFloat(lo.FromPtr(instanceType.Vcpu), 64)
+	ramMib, _ := strconv.Atoi(lo.FromPtr(instanceType.Ram))
+
+	return map[string]any{
+		FieldCountableInstanceTypeClusterId:    lo.FromPtr(item.ClusterId),
+		FieldCountableInstanceTypeInstanceType: lo.FromPtr(instanceType.InstanceType),
+		FieldCountableInstanceTypeProvider:     lo.FromPtr(instanceType.Provider),
+		FieldCountableInstanceTypeRegion:       lo.FromPtr(instanceType.Region),
+		FieldCountableInstanceTypeCount:        int(lo.FromPtr(item.Count)),
+		FieldCountableInstanceTypeVcpu:         vcpu,
+		FieldCountableInstanceTypeRamMib:       ramMib,
+	}
+}
@@ -0,0 +1,68 @@
+package castai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/reservations"
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+func TestReservationsBalanceDataSourceRead(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{api: &sdk.ClientWithResponses{ClientInterface: mockClient}}
+
+	organizationId := "7a704518-8275-4721-a622-18f4ec13fc22"
+	mockClient.EXPECT().
+		InventoryAPIGetReservationsBalance(gomock.Any(), organizationId).
+		Return(jsonResponse(200, `{"reservations": [
+			{
+			  "reservation": {"reservationId": "f3b2a1c0-9d8e-4f7a-b6c5-d4e3f2a1b0c9", "name": "ri-1", "provider": "aws", "region": "us-east-1",
+			                  "instanceType": "m5.large", "count": 5, "startDate": "2024-01-01T00:00:00Z", "endDate": "2025-01-01T00:00:00Z"},
+			  "usage": 0.4,
+			  "instanceTypes": [
+				{"clusterId": "b6bfc074-a267-400f-b8f1-db0850c369b1", "count": 1, "instanceType": {"instanceType": "m5.large", "provider": "aws", "region": "us-east-1", "vcpu": "2", "ram": "8192"}},
+				{"clusterId": "0d1e2f3a-4b5c-4d6e-8f7a-9b0c1d2e3f4a", "count": 1, "instanceType": {"instanceType": "m5.large", "provider": "aws", "region": "us-east-1", "vcpu": "2", "ram": "8192"}}
+			  ]
+			},
+			{
+			  "reservation": {"reservationId": "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", "name": "cud-1", "provider": "gcp", "count": 1, "endDate": null},
+			  "usage": 1,
+			  "instanceTypes": [
+				{"clusterId": "b6bfc074-a267-400f-b8f1-db0850c369b1", "count": 3, "instanceType": {"instanceType": "e2-micro", "vcpu": "0.25", "ram": "1024"}}
+			  ]
+			}
+		]}`), nil)
+
+	resource := dataSourceReservationsBalance()
+	val := cty.ObjectVal(map[string]cty.Value{
+		reservations.FieldReservationsOrganizationId: cty.StringVal(organizationId),
+	})
+	data := resource.Data(terraform.NewInstanceStateShimmedFromValue(val, 0))
+
+	result := resource.ReadContext(context.Background(), data, provider)
+	r.Nil(result)
+
+	r.Equal(organizationId, data.Id())
+	r.Equal(2, data.Get("reservations.#"))
+	r.Equal("ri-1", data.Get("reservations.0.name"))
+	r.Equal(5, data.Get("reservations.0.count"))
+	r.Equal("2025-01-01T00:00:00Z", data.Get("reservations.0.end_date"))
+	r.Equal(0.4, data.Get("reservations.0.usage"))
+	r.Equal(2, data.Get("reservations.0.used_count"))
+	r.Equal(3, data.Get("reservations.0.remaining_count"))
+	r.Equal(2, data.Get("reservations.0.instance_types.#"))
+	r.Equal("0d1e2f3a-4b5c-4d6e-8f7a-9b0c1d2e3f4a", data.Get("reservations.0.instance_types.1.cluster_id"))
+	r.Equal(8192, data.Get("reservations.0.instance_types.1.ram_mib"))
+	r.Equal("", data.Get("reservations.1.end_date"))
+	r.Equal(3, data.Get("reservations.1.used_count"))
+	r.Equal(0, data.Get("reservations.1.remaining_count"))
+	r.Equal(0.25, data.Get("reservations.1.instance_types.0.vcpu"))
+}
@@ -0,0 +1,117 @@
+package castai
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+
+	"github.com/castai/terraform-provider-castai/castai/reservations"
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+)
+
+const (
+	FieldResourceUsageInstanceTypes = "instance_types"
+	FieldResourceUsageInstanceCount = "instance_count"
+	FieldResourceUsageVcpu          = "vcpu"
+	FieldResourceUsageRamMib        = "ram_mib"
+)
+
+func dataSourceResourceUsage() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceResourceUsageRead,
+		Description: "Retrieve instances used by clusters of an organization, optionally limited to a single cluster.",
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(2 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			reservations.FieldReservationsOrganizationId: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsUUID),
+				Description:      "organization, defaults to the only organization of the user",
+			},
+			FieldClusterID: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsUUID),
+				Description:      "CAST AI cluster id to limit the usage to",
+			},
+			FieldResourceUsageInstanceTypes: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Resource{Schema: countableInstanceTypeFields()},
+				Description: "used instances by instance type and cluster",
+			},
+			FieldResourceUsageInstanceCount: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "total amount of used instances",
+			},
+			FieldResourceUsageVcpu: {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "total vCPUs of used instances",
+			},
+			FieldResourceUsageRamMib: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "total memory of used instances in MiB",
+			},
+		},
+	}
+}
+
+func dataSourceResourceUsageRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ProviderConfig).api
+
+	organizationId, err := getOrganizationId(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := client.InventoryAPIGetResourceUsageWithResponse(ctx, organizationId)
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return diag.FromErr(fmt.Errorf("fetching resource usage: %w", checkErr))
+	}
+
+	clusterId := d.Get(FieldClusterID).(string)
+	instanceTypes := lo.Filter(lo.FromPtr(resp.JSON200.InstanceTypes), func(item sdk.CastaiInventoryV1beta1CountableInstanceType, _ int) bool {
+		return clusterId == "" || lo.FromPtr(item.ClusterId) == clusterId
+	})
+	usage := lo.Map(instanceTypes, countableInstanceTypeToState)
+
+	var (
+		instanceCount int
+		vcpu          float64
+		ramMib        int
+	)
+	for _, item := range usage {
+		count := item[FieldCountableInstanceTypeCount].(int)
+		instanceCount += count
+		vcpu += float64(count) * item[FieldCountableInstanceTypeVcpu].(float64)
+		ramMib += count * item[FieldCountableInstanceTypeRamMib].(int)
+	}
+
+	d.SetId(lo.Ternary(clusterId == "", organizationId, organizationId+"/"+clusterId))
+	for field, value := range map[string]any{
+		reservations.FieldReservationsOrganizationId: organizationId,
+		FieldResourceUsageInstanceTypes:              usage,
+		FieldResourceUsageInstanceCount:              instanceCount,
+		FieldResourceUsageVcpu:                       vcpu,
+		FieldResourceUsageRamMib:                     ramMib,
+	} {
+		if err := d.Set(field, value); err != nil {
+			return diag.FromErr(fmt.Errorf("setting %s: %w", field, err))
+		}
+	}
+
+	return nil
+}
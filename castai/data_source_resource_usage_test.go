@@ -0,0 +1,79 @@
+package castai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/reservations"
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+func TestResourceUsageDataSourceRead(t *testing.T) {
+	organizationId := "7a704518-8275-4721-a622-18f4ec13fc22"
+	clusterId := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	body := `{"instanceTypes": [
+		{"clusterId": "b6bfc074-a267-400f-b8f1-db0850c369b1", "count": 3, "instanceType": {"instanceType": "m5.large", "provider": "aws", "region": "us-east-1", "vcpu": "2", "ram": "8192"}},
+		{"clusterId": "b6bfc074-a267-400f-b8f1-db0850c369b1", "count": 2, "instanceType": {"instanceType": "c5.xlarge", "provider": "aws", "region": "us-east-1", "vcpu": "4", "ram": "8192"}},
+		{"clusterId": "0d1e2f3a-4b5c-4d6e-8f7a-9b0c1d2e3f4a", "count": 4, "instanceType": {"instanceType": "e2-micro", "provider": "gcp", "region": "us-central1", "vcpu": "0.25", "ram": "1024"}}
+	]}`
+
+	tt := map[string]struct {
+		clusterId     string
+		id            string
+		instanceTypes int
+		instanceCount int
+		vcpu          float64
+		ramMib        int
+	}{
+		"organization": {
+			id:            organizationId,
+			instanceTypes: 3,
+			instanceCount: 9,
+			vcpu:          15,
+			ramMib:        45056,
+		},
+		"cluster": {
+			clusterId:     clusterId,
+			id:            organizationId + "/" + clusterId,
+			instanceTypes: 2,
+			instanceCount: 5,
+			vcpu:          14,
+			ramMib:        40960,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+			provider := &ProviderConfig{api: &sdk.ClientWithResponses{ClientInterface: mockClient}}
+
+			mockClient.EXPECT().
+				InventoryAPIGetResourceUsage(gomock.Any(), organizationId).
+				Return(jsonResponse(200, body), nil)
+
+			resource := dataSourceResourceUsage()
+			val := cty.ObjectVal(map[string]cty.Value{
+				reservations.FieldReservationsOrganizationId: cty.StringVal(organizationId),
+				FieldClusterID: cty.StringVal(tc.clusterId),
+			})
+			data := resource.Data(terraform.NewInstanceStateShimmedFromValue(val, 0))
+
+			result := resource.ReadContext(context.Background(), data, provider)
+			r.Nil(result)
+
+			r.Equal(tc.id, data.Id())
+			r.Equal(tc.instanceTypes, data.Get("instance_types.#"))
+			r.Equal("m5.large", data.Get("instance_types.0.instance_type"))
+			r.Equal(tc.instanceCount, data.Get(FieldResourceUsageInstanceCount))
+			r.Equal(tc.vcpu, data.Get(FieldResourceUsageVcpu))
+			r.Equal(tc.ramMib, data.Get(FieldResourceUsageRamMib))
+		})
+	}
+}
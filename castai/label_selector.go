@@ -0,0 +1,119 @@
+package castai
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+
+	castval "github.com/castai/terraform-provider-castai/castai/validation"
+)
+
+const (
+	labelSelectorOpIn           = "In"
+	labelSelectorOpNotIn        = "NotIn"
+	labelSelectorOpExists       = "Exists"
+	labelSelectorOpDoesNotExist = "DoesNotExist"
+	labelSelectorOpGt           = "Gt"
+	labelSelectorOpLt           = "Lt"
+)
+
+var (
+	// labelSelectorOperators are supported by Kubernetes label selectors.
+	labelSelectorOperators = []string{labelSelectorOpIn, labelSelectorOpNotIn, labelSelectorOpExists, labelSelectorOpDoesNotExist}
+	// nodeSelectorOperators are supported by Kubernetes node selectors, which can compare labels as integers as well.
+	nodeSelectorOperators = []string{labelSelectorOpIn, labelSelectorOpNotIn, labelSelectorOpExists, labelSelectorOpDoesNotExist, labelSelectorOpGt, labelSelectorOpLt}
+)
+
+// labelSelectorFields returns match_labels and match_expressions attributes shared by selectors of evictor advanced config
+// and rebalancing schedule. Operators limit the ones accepted in match expressions. Label and operator formats are only
+// warned about, as evictor advanced config accepted any value before.
+func labelSelectorFields(operators []string) map[string]*schema.Schema {
+	valuesDescription := "Label values. Required by In and NotIn, has to be empty for Exists and DoesNotExist."
+	if lo.Contains(operators, labelSelectorOpGt) {
+		valuesDescription = "Label values. Required by In and NotIn, exactly one integer for Gt and Lt, has to be empty for Exists and DoesNotExist."
+	}
+
+	return map[string]*schema.Schema{
+		FieldMatchLabels: {
+			Type:             schema.TypeMap,
+			Optional:         true,
+			Elem:             &schema.Schema{Type: schema.TypeString},
+			ValidateDiagFunc: castval.Warning(castval.ValidKubernetesLabels()),
+			Description:      "Labels which have to be set with exactly the given values.",
+		},
+		FieldMatchExpressions: {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					FieldMatchExpressionKey: {
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateDiagFunc: castval.Warning(castval.ValidKubernetesLabelKey()),
+						Description:      "Label key the expression applies to.",
+					},
+					FieldMatchExpressionOp: {
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateDiagFunc: castval.Warning(validation.ToDiagFunc(validation.StringInSlice(operators, false))),
+						Description:      fmt.Sprintf("Relation of the label to the values. Supported values: %s.", strings.Join(operators, ", ")),
+					},
+					FieldMatchExpressionVal: {
+						Type:        schema.TypeList,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Optional:    true,
+						Description: valuesDescription,
+					},
+				},
+			},
+			Description: "Label expressions which all have to be satisfied.",
+		},
+	}
+}
+
+// validateLabelSelector rejects values of match expressions of the selector at path which their operators can't be used with.
+// Expressions are skipped while their operator or values are not known yet, or when the operator isn't one of operators.
+func validateLabelSelector(d *schema.ResourceDiff, path string, operators []string) error {
+	var result *multierror.Error
+
+	expressions, _ := d.Get(path + "." + FieldMatchExpressions).([]any)
+	for i := range expressions {
+		expressionPath := fmt.Sprintf("%s.%s.%d", path, FieldMatchExpressions, i)
+		if !d.NewValueKnown(expressionPath+"."+FieldMatchExpressionOp) || !d.NewValueKnown(expressionPath+"."+FieldMatchExpressionVal) {
+			continue
+		}
+
+		operator := d.Get(expressionPath + "." + FieldMatchExpressionOp).(string)
+		if !lo.Contains(operators, operator) {
+			continue
+		}
+		values := toStringList(d.Get(expressionPath + "." + FieldMatchExpressionVal).([]any))
+		if err := validateMatchExpressionValues(operator, values); err != nil {
+			result = multierror.Append(result, fmt.Errorf("%s: %w", expressionPath, err))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func validateMatchExpressionValues(operator string, values []string) error {
+	switch operator {
+	case labelSelectorOpExists, labelSelectorOpDoesNotExist:
+		if len(values) != 0 {
+			return fmt.Errorf("operator %s does not accept values, got %d", operator, len(values))
+		}
+	case labelSelectorOpGt, labelSelectorOpLt:
+		if len(values) != 1 {
+			return fmt.Errorf("operator %s requires exactly one value, got %d", operator, len(values))
+		}
+		if _, err := strconv.ParseInt(values[0], 10, 64); err != nil {
+			return fmt.Errorf("operator %s requires an integer value, got %q", operator, values[0])
+		}
+	}
+	return nil
+}
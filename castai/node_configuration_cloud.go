@@ -0,0 +1,135 @@
+package castai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/samber/lo"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+)
+
+var (
+	// nodeConfigurationProviderBlocks are named after the type of the cluster they are applicable for.
+	nodeConfigurationProviderBlocks = []string{
+		FieldNodeConfigurationEKS,
+		FieldNodeConfigurationGKE,
+		FieldNodeConfigurationAKS,
+		FieldNodeConfigurationKOPS,
+	}
+
+	// nodeConfigurationEKSOnlyFields are ignored by the API on clusters other than EKS.
+	nodeConfigurationEKSOnlyFields = []string{
+		FieldNodeConfigurationContainerRuntime,
+		FieldNodeConfigurationDockerConfig,
+		FieldNodeConfigurationDocker,
+		FieldNodeConfigurationKubeletConfig,
+		FieldNodeConfigurationKubelet,
+	}
+)
+
+func getCluster(ctx context.Context, client *sdk.ClientWithResponses, clusterID string) (*sdk.ExternalclusterV1Cluster, error) {
+	resp, err := client.ExternalClusterAPIGetClusterWithResponse(ctx, clusterID)
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return nil, fmt.Errorf("retrieving cluster: %w", checkErr)
+	}
+	return resp.JSON200, nil
+}
+
+// clusterGetter returns function retrieving the cluster on the first call only, so that the cluster is requested
+// at most once per operation.
+func clusterGetter(ctx context.Context, client *sdk.ClientWithResponses, clusterID string) func() (*sdk.ExternalclusterV1Cluster, error) {
+	var (
+		cluster *sdk.ExternalclusterV1Cluster
+		err     error
+		done    bool
+	)
+	return func() (*sdk.ExternalclusterV1Cluster, error) {
+		if !done {
+			cluster, err = getCluster(ctx, client, clusterID)
+			done = true
+		}
+		return cluster, err
+	}
+}
+
+// clusterType returns type of the cluster matching name of the node configuration block, or empty string if it has none.
+func clusterType(cluster *sdk.ExternalclusterV1Cluster) string {
+	switch {
+	case cluster.Eks != nil:
+		return FieldNodeConfigurationEKS
+	case cluster.Gke != nil:
+		return FieldNodeConfigurationGKE
+	case cluster.Aks != nil:
+		return FieldNodeConfigurationAKS
+	case cluster.Kops != nil:
+		return FieldNodeConfigurationKOPS
+	}
+	return ""
+}
+
+// nodeConfigurationCloudDiff checks that provider specific blocks match the type of the cluster instead of failing the API call.
+func nodeConfigurationCloudDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	isSet := func(field string, _ int) bool {
+		_, ok := diff.GetOk(field)
+		return ok
+	}
+	blocks := lo.Filter(nodeConfigurationProviderBlocks, isSet)
+	eksOnlyFields := lo.Filter(nodeConfigurationEKSOnlyFields, isSet)
+	if len(blocks) == 0 && len(eksOnlyFields) == 0 {
+		return nil
+	}
+	if !diff.NewValueKnown(FieldClusterID) {
+		return nil
+	}
+
+	clusterID := diff.Get(FieldClusterID).(string)
+	cluster, err := getCluster(ctx, meta.(*ProviderConfig).api, clusterID)
+	if err != nil {
+		return err
+	}
+	typ := clusterType(cluster)
+	if typ == "" {
+		return nil
+	}
+
+	for _, block := range blocks {
+		if block != typ {
+			return fmt.Errorf("%s block can't be used on %s cluster %q, use %s block instead", block, typ, clusterID, typ)
+		}
+	}
+	return nil
+}
+
+// nodeConfigurationEKSOnlyFieldsWarnings warns about fields ignored on the cluster. CustomizeDiff can't emit warnings,
+// so they are returned on apply instead.
+func nodeConfigurationEKSOnlyFieldsWarnings(d *schema.ResourceData, getCluster func() (*sdk.ExternalclusterV1Cluster, error)) diag.Diagnostics {
+	eksOnlyFields := lo.Filter(nodeConfigurationEKSOnlyFields, func(field string, _ int) bool {
+		_, ok := d.GetOk(field)
+		return ok
+	})
+	if len(eksOnlyFields) == 0 {
+		return nil
+	}
+
+	cluster, err := getCluster()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	typ := clusterType(cluster)
+	if typ == "" || typ == FieldNodeConfigurationEKS {
+		return nil
+	}
+
+	return lo.Map(eksOnlyFields, func(field string, _ int) diag.Diagnostic {
+		return diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       fmt.Sprintf("%s is applicable for EKS clusters only", field),
+			Detail:        fmt.Sprintf("The attribute is ignored on %s cluster %q.", typ, d.Get(FieldClusterID).(string)),
+			AttributePath: cty.GetAttrPath(field),
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package castai
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+// newClusterProvider returns provider config with the cluster lookup returning given cluster.
+func newClusterProvider(t *testing.T, clusterID, cluster string) *ProviderConfig {
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	mockClient.EXPECT().
+		ExternalClusterAPIGetCluster(gomock.Any(), clusterID).
+		DoAndReturn(func(_ context.Context, _ string) (*http.Response, error) {
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(cluster))), Header: map[string][]string{"Content-Type": {"json"}}}, nil
+		}).AnyTimes()
+
+	return &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+}
+
+func TestNodeConfigurationResourceCustomizeDiff_cloud(t *testing.T) {
+	clusterID := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	eksCluster := `{"id": "` + clusterID + `", "eks": {"region": "eu-central-1"}}`
+	gkeCluster := `{"id": "` + clusterID + `", "gke": {"region": "europe-west1"}}`
+	aksCluster := `{"id": "` + clusterID + `", "aks": {"region": "westeurope"}}`
+
+	eks := cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+		"instance_profile_arn": cty.StringVal("arn:aws:iam::123456789012:instance-profile/castai-eks-instance"),
+		"security_groups":      cty.ListVal([]cty.Value{cty.StringVal("sg-0123456789")}),
+	})})
+	gke := cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+		"max_pods_per_node": cty.NumberIntVal(64),
+	})})
+
+	tt := map[string]struct {
+		cluster    string
+		attributes map[string]cty.Value
+		errMsg     string
+	}{
+		"eks block on eks cluster": {
+			cluster:    eksCluster,
+			attributes: map[string]cty.Value{FieldNodeConfigurationEKS: eks},
+		},
+		"gke block on gke cluster": {
+			cluster:    gkeCluster,
+			attributes: map[string]cty.Value{FieldNodeConfigurationGKE: gke},
+		},
+		"eks block on gke cluster": {
+			cluster:    gkeCluster,
+			attributes: map[string]cty.Value{FieldNodeConfigurationEKS: eks},
+			errMsg:     `eks block can't be used on gke cluster "b6bfc074-a267-400f-b8f1-db0850c369b1", use gke block instead`,
+		},
+		"gke block on aks cluster": {
+			cluster:    aksCluster,
+			attributes: map[string]cty.Value{FieldNodeConfigurationGKE: gke},
+			errMsg:     `gke block can't be used on aks cluster`,
+		},
+		"eks only field on aks cluster": {
+			cluster: aksCluster,
+			attributes: map[string]cty.Value{
+				FieldNodeConfigurationContainerRuntime: cty.StringVal("containerd"),
+				FieldNodeConfigurationDockerConfig:     cty.StringVal(`{"max-concurrent-downloads": 5}`),
+			},
+		},
+		"cluster of unknown type": {
+			cluster:    `{"id": "` + clusterID + `"}`,
+			attributes: map[string]cty.Value{FieldNodeConfigurationEKS: eks},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			resource := resourceNodeConfiguration()
+
+			attributes := map[string]cty.Value{
+				FieldClusterID:                cty.StringVal(clusterID),
+				FieldNodeConfigurationName:    cty.StringVal("default"),
+				FieldNodeConfigurationSubnets: cty.ListVal([]cty.Value{cty.StringVal("subnet-1")}),
+			}
+			for k, v := range tc.attributes {
+				attributes[k] = v
+			}
+			val := cty.ObjectVal(attributes)
+			state := &terraform.InstanceState{RawConfig: val}
+			config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+			_, err := resource.Diff(context.Background(), state, config, newClusterProvider(t, clusterID, tc.cluster))
+			if tc.errMsg == "" {
+				r.NoError(err)
+				return
+			}
+			r.Error(err)
+			r.Contains(err.Error(), tc.errMsg)
+		})
+	}
+}
+
+func TestNodeConfigurationResourceCustomizeDiff_cloudWithoutProviderBlocks(t *testing.T) {
+	r := require.New(t)
+	resource := resourceNodeConfiguration()
+
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterID:                cty.StringVal("b6bfc074-a267-400f-b8f1-db0850c369b1"),
+		FieldNodeConfigurationName:    cty.StringVal("default"),
+		FieldNodeConfigurationSubnets: cty.ListVal([]cty.Value{cty.StringVal("subnet-1")}),
+	})
+	state := &terraform.InstanceState{RawConfig: val}
+	config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+	// Cluster isn't looked up when nothing cloud specific is configured.
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{api: &sdk.ClientWithResponses{ClientInterface: mockClient}}
+
+	_, err := resource.Diff(context.Background(), state, config, provider)
+	r.NoError(err)
+}
+
+func TestNodeConfigurationEKSOnlyFieldsWarnings(t *testing.T) {
+	clusterID := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+
+	tt := map[string]struct {
+		cluster  string
+		warnings []string
+	}{
+		"eks cluster": {
+			cluster: `{"id": "` + clusterID + `", "eks": {"region": "eu-central-1"}}`,
+		},
+		"aks cluster": {
+			cluster:  `{"id": "` + clusterID + `", "aks": {"region": "westeurope"}}`,
+			warnings: []string{"container_runtime is applicable for EKS clusters only", "docker_config is applicable for EKS clusters only"},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			resource := resourceNodeConfiguration()
+
+			val := cty.ObjectVal(map[string]cty.Value{
+				FieldClusterID:                         cty.StringVal(clusterID),
+				FieldNodeConfigurationName:             cty.StringVal("default"),
+				FieldNodeConfigurationContainerRuntime: cty.StringVal("containerd"),
+				FieldNodeConfigurationDockerConfig:     cty.StringVal(`{"max-concurrent-downloads": 5}`),
+			})
+			data := resource.Data(terraform.NewInstanceStateShimmedFromValue(val, 0))
+			provider := newClusterProvider(t, clusterID, tc.cluster)
+
+			diags := nodeConfigurationEKSOnlyFieldsWarnings(data, clusterGetter(context.Background(), provider.api, clusterID))
+			r.False(diags.HasError())
+			r.Len(diags, len(tc.warnings))
+			for i, summary := range tc.warnings {
+				r.Equal(diag.Warning, diags[i].Severity)
+				r.Equal(summary, diags[i].Summary)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package castai
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/samber/lo"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+)
+
+func setDefaultNodeConfiguration(ctx context.Context, client *sdk.ClientWithResponses, clusterID, id string) error {
+	resp, err := client.NodeConfigurationAPISetDefaultWithResponse(ctx, clusterID, id)
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return fmt.Errorf("setting node configuration %q as default: %w", id, checkErr)
+	}
+	return nil
+}
+
+// updateNodeConfigurationDefault promotes the node configuration to default, or hands the default over to another one.
+func updateNodeConfigurationDefault(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChange(FieldNodeConfigurationDefault) {
+		return nil
+	}
+
+	client := meta.(*ProviderConfig).api
+	clusterID := d.Get(FieldClusterID).(string)
+	if d.Get(FieldNodeConfigurationDefault).(bool) {
+		if err := setDefaultNodeConfiguration(ctx, client, clusterID, d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
+		return nil
+	}
+
+	// Cluster always has a default node configuration, so it can only be handed over to another one.
+	if fallbackID := d.Get(FieldNodeConfigurationFallbackConfigurationID).(string); fallbackID != "" {
+		if err := setDefaultNodeConfiguration(ctx, client, clusterID, fallbackID); err != nil {
+			return diag.FromErr(err)
+		}
+		return nil
+	}
+
+	// Without a fallback the cluster would be left without a default, unless another configuration was promoted already.
+	isDefault, _, err := nodeConfigurationDefaultState(ctx, client, clusterID, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if isDefault {
+		return diag.Errorf("node configuration %q can't stop being the default one: "+
+			"set %s, or set %s on another node configuration first",
+			d.Get(FieldNodeConfigurationName).(string), FieldNodeConfigurationFallbackConfigurationID, FieldNodeConfigurationDefault)
+	}
+	return nil
+}
+
+// handOverNodeConfigurationDefault makes sure the default node configuration can be deleted by promoting the fallback one.
+// Without a fallback, another configuration has to be the default one already. Returns false when the configuration is the last one of the cluster.
+func handOverNodeConfigurationDefault(ctx context.Context, d *schema.ResourceData, meta interface{}) (bool, error) {
+	client := meta.(*ProviderConfig).api
+	clusterID := d.Get(FieldClusterID).(string)
+
+	if fallbackID := d.Get(FieldNodeConfigurationFallbackConfigurationID).(string); fallbackID != "" {
+		log.Printf("[INFO] Promoting node configuration (%s) to default before deleting (%s)", fallbackID, d.Id())
+		return true, setDefaultNodeConfiguration(ctx, client, clusterID, fallbackID)
+	}
+
+	isDefault, others, err := nodeConfigurationDefaultState(ctx, client, clusterID, d.Id())
+	if err != nil {
+		return false, err
+	}
+	if !isDefault {
+		return true, nil
+	}
+	if len(others) == 0 {
+		return false, nil
+	}
+	return false, fmt.Errorf("node configuration %q is the default one and deleting it would leave the cluster without a default: "+
+		"set %s, or set %s on another node configuration before deleting it, cluster has other node configurations: %v",
+		d.Get(FieldNodeConfigurationName).(string), FieldNodeConfigurationFallbackConfigurationID, FieldNodeConfigurationDefault, others)
+}
+
+// nodeConfigurationDefaultState returns whether the node configuration is the default one, and names of other configurations of the cluster.
+func nodeConfigurationDefaultState(ctx context.Context, client *sdk.ClientWithResponses, clusterID, id string) (bool, []string, error) {
+	resp, err := client.NodeConfigurationAPIListConfigurationsWithResponse(ctx, clusterID)
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return false, nil, fmt.Errorf("listing node configurations: %w", checkErr)
+	}
+
+	var isDefault bool
+	var others []string
+	for _, cfg := range lo.FromPtr(resp.JSON200.Items) {
+		if lo.FromPtr(cfg.Id) == id {
+			isDefault = lo.FromPtr(cfg.Default)
+			continue
+		}
+		others = append(others, lo.FromPtr(cfg.Name))
+	}
+	return isDefault, others, nil
+}
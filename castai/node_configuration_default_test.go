@@ -0,0 +1,259 @@
+package castai
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+func TestNodeConfigurationResourceCreate_default(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterID := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	configID := "c0e6d9ac-dbe6-4a8f-8a2e-92e7a7b9a1f5"
+	body := func(isDefault bool) io.ReadCloser {
+		return nodeConfigurationBody(configID, "default", isDefault)
+	}
+
+	gomock.InOrder(
+		mockClient.EXPECT().
+			NodeConfigurationAPICreateConfiguration(gomock.Any(), clusterID, gomock.Any()).
+			Return(&http.Response{StatusCode: 200, Body: body(false), Header: map[string][]string{"Content-Type": {"json"}}}, nil),
+		mockClient.EXPECT().
+			NodeConfigurationAPISetDefault(gomock.Any(), clusterID, configID).
+			Return(&http.Response{StatusCode: 200, Body: body(true), Header: map[string][]string{"Content-Type": {"json"}}}, nil),
+		mockClient.EXPECT().
+			NodeConfigurationAPIGetConfiguration(gomock.Any(), clusterID, configID).
+			Return(&http.Response{StatusCode: 200, Body: body(true), Header: map[string][]string{"Content-Type": {"json"}}}, nil),
+	)
+
+	resource := resourceNodeConfiguration()
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterID:                cty.StringVal(clusterID),
+		FieldNodeConfigurationName:    cty.StringVal("default"),
+		FieldNodeConfigurationSubnets: cty.ListVal([]cty.Value{cty.StringVal("subnet-1")}),
+		FieldNodeConfigurationDefault: cty.True,
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+
+	data := resource.Data(state)
+	result := resource.CreateContext(context.Background(), data, provider)
+	r.Nil(result)
+	r.Equal(true, data.Get(FieldNodeConfigurationDefault))
+}
+
+func TestNodeConfigurationResourceUpdate_defaultFallback(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterID := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	configID := "c0e6d9ac-dbe6-4a8f-8a2e-92e7a7b9a1f5"
+	fallbackID := "7dc4f922-29c9-4377-889c-0c8c5fb8d497"
+
+	mockClient.EXPECT().
+		NodeConfigurationAPISetDefault(gomock.Any(), clusterID, fallbackID).
+		Return(&http.Response{StatusCode: 200, Body: nodeConfigurationBody(fallbackID, "fallback", true), Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+
+	resource := resourceNodeConfiguration()
+	attributes := map[string]cty.Value{
+		FieldClusterID:                cty.StringVal(clusterID),
+		FieldNodeConfigurationName:    cty.StringVal("default"),
+		FieldNodeConfigurationSubnets: cty.ListVal([]cty.Value{cty.StringVal("subnet-1")}),
+		FieldNodeConfigurationDefault: cty.True,
+	}
+	state := terraform.NewInstanceStateShimmedFromValue(cty.ObjectVal(attributes), 0)
+	state.ID = configID
+	state.Attributes[FieldNodeConfigurationDiskCpuRatio] = "0"
+	state.Attributes[FieldNodeConfigurationMinDiskSize] = "100"
+
+	attributes[FieldNodeConfigurationDefault] = cty.False
+	attributes[FieldNodeConfigurationFallbackConfigurationID] = cty.StringVal(fallbackID)
+	val := cty.ObjectVal(attributes)
+	state.RawConfig = val
+	config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+	diff, err := resource.Diff(context.Background(), state, config, provider)
+	r.NoError(err)
+	diff.RawConfig = val
+
+	_, diags := resource.Apply(context.Background(), state, diff, provider)
+	r.Nil(diags)
+}
+
+func TestNodeConfigurationResourceUpdate_defaultWithoutFallback(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterID := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	configID := "c0e6d9ac-dbe6-4a8f-8a2e-92e7a7b9a1f5"
+
+	mockClient.EXPECT().
+		NodeConfigurationAPIListConfigurations(gomock.Any(), clusterID).
+		Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"items": [
+			{"id": "` + configID + `", "name": "default", "default": true},
+			{"id": "7dc4f922-29c9-4377-889c-0c8c5fb8d497", "name": "other", "default": false}
+		]}`))), Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+
+	resource := resourceNodeConfiguration()
+	attributes := map[string]cty.Value{
+		FieldClusterID:                cty.StringVal(clusterID),
+		FieldNodeConfigurationName:    cty.StringVal("default"),
+		FieldNodeConfigurationSubnets: cty.ListVal([]cty.Value{cty.StringVal("subnet-1")}),
+		FieldNodeConfigurationDefault: cty.True,
+	}
+	state := terraform.NewInstanceStateShimmedFromValue(cty.ObjectVal(attributes), 0)
+	state.ID = configID
+	state.Attributes[FieldNodeConfigurationDiskCpuRatio] = "0"
+	state.Attributes[FieldNodeConfigurationMinDiskSize] = "100"
+
+	attributes[FieldNodeConfigurationDefault] = cty.False
+	val := cty.ObjectVal(attributes)
+	state.RawConfig = val
+	config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+	diff, err := resource.Diff(context.Background(), state, config, provider)
+	r.NoError(err)
+	diff.RawConfig = val
+
+	_, diags := resource.Apply(context.Background(), state, diff, provider)
+	r.True(diags.HasError())
+	r.Equal(`node configuration "default" can't stop being the default one: set fallback_configuration_id, or set default on another node configuration first`, diags[0].Summary)
+}
+
+func TestNodeConfigurationResourceDelete_default(t *testing.T) {
+	clusterID := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	configID := "c0e6d9ac-dbe6-4a8f-8a2e-92e7a7b9a1f5"
+	otherID := "7dc4f922-29c9-4377-889c-0c8c5fb8d497"
+
+	tt := map[string]struct {
+		fallbackID string
+		others     bool
+		expect     func(mockClient *mock_sdk.MockClientInterface)
+		errMsg     string
+	}{
+		"promotes fallback configuration": {
+			fallbackID: otherID,
+			expect: func(mockClient *mock_sdk.MockClientInterface) {
+				gomock.InOrder(
+					mockClient.EXPECT().
+						NodeConfigurationAPISetDefault(gomock.Any(), clusterID, otherID).
+						Return(&http.Response{StatusCode: 200, Body: nodeConfigurationBody(otherID, "other", true), Header: map[string][]string{"Content-Type": {"json"}}}, nil),
+					mockClient.EXPECT().
+						NodeConfigurationAPIDeleteConfiguration(gomock.Any(), clusterID, configID).
+						Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{}`))), Header: map[string][]string{"Content-Type": {"json"}}}, nil),
+				)
+			},
+		},
+		"deletes when another configuration is the default one": {
+			expect: func(mockClient *mock_sdk.MockClientInterface) {
+				gomock.InOrder(
+					mockClient.EXPECT().
+						NodeConfigurationAPIListConfigurations(gomock.Any(), clusterID).
+						Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"items": [
+							{"id": "` + configID + `", "name": "default", "default": false},
+							{"id": "` + otherID + `", "name": "other", "default": true}
+						]}`))), Header: map[string][]string{"Content-Type": {"json"}}}, nil),
+					mockClient.EXPECT().
+						NodeConfigurationAPIDeleteConfiguration(gomock.Any(), clusterID, configID).
+						Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{}`))), Header: map[string][]string{"Content-Type": {"json"}}}, nil),
+				)
+			},
+		},
+		"keeps the last configuration": {
+			expect: func(mockClient *mock_sdk.MockClientInterface) {
+				mockClient.EXPECT().
+					NodeConfigurationAPIListConfigurations(gomock.Any(), clusterID).
+					Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"items": [
+						{"id": "` + configID + `", "name": "default", "default": true}
+					]}`))), Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+			},
+		},
+		"refuses when other configurations exist": {
+			expect: func(mockClient *mock_sdk.MockClientInterface) {
+				mockClient.EXPECT().
+					NodeConfigurationAPIListConfigurations(gomock.Any(), clusterID).
+					Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"items": [
+						{"id": "` + configID + `", "name": "default", "default": true},
+						{"id": "` + otherID + `", "name": "other", "default": false}
+					]}`))), Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+			},
+			errMsg: `node configuration "default" is the default one and deleting it would leave the cluster without a default: set fallback_configuration_id, or set default on another node configuration before deleting it`,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+			provider := &ProviderConfig{
+				api: &sdk.ClientWithResponses{
+					ClientInterface: mockClient,
+				},
+			}
+
+			mockClient.EXPECT().
+				NodeConfigurationAPIGetConfiguration(gomock.Any(), clusterID, configID).
+				Return(&http.Response{StatusCode: 200, Body: nodeConfigurationBody(configID, "default", true), Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+			tc.expect(mockClient)
+
+			resource := resourceNodeConfiguration()
+			val := cty.ObjectVal(map[string]cty.Value{
+				FieldClusterID:                                cty.StringVal(clusterID),
+				FieldNodeConfigurationName:                    cty.StringVal("default"),
+				FieldNodeConfigurationFallbackConfigurationID: cty.StringVal(tc.fallbackID),
+			})
+			state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+			state.ID = configID
+
+			data := resource.Data(state)
+			result := resource.DeleteContext(context.Background(), data, provider)
+			if tc.errMsg == "" {
+				r.Nil(result)
+				return
+			}
+			r.True(result.HasError())
+			r.Contains(result[0].Summary, tc.errMsg)
+		})
+	}
+}
+
+func nodeConfigurationBody(id, name string, isDefault bool) io.ReadCloser {
+	d := "false"
+	if isDefault {
+		d = "true"
+	}
+	return io.NopCloser(bytes.NewReader([]byte(`{
+	  "id": "` + id + `",
+	  "name": "` + name + `",
+	  "default": ` + d + `,
+	  "minDiskSize": 100,
+	  "subnets": ["subnet-1"],
+	  "tags": {}
+	}`)))
+}
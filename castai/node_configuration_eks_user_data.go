@@ -0,0 +1,347 @@
+package castai
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	FieldEKSBottlerocket = "bottlerocket"
+	FieldEKSAL2023       = "al2023"
+
+	FieldBottlerocketAdminContainerEnabled   = "admin_container_enabled"
+	FieldBottlerocketControlContainerEnabled = "control_container_enabled"
+	FieldBottlerocketMaxPods                 = "max_pods"
+	FieldBottlerocketNodeLabels              = "node_labels"
+	FieldBottlerocketNodeTaints              = "node_taints"
+	FieldBottlerocketKernelSysctl            = "kernel_sysctl"
+	FieldBottlerocketSettings                = "settings"
+
+	FieldAL2023KubeletFlags         = "kubelet_flags"
+	FieldAL2023LocalStorageStrategy = "local_storage_strategy"
+	FieldAL2023ContainerdConfig     = "containerd_config"
+
+	nodeadmContentType = "application/node.eks.aws"
+)
+
+var (
+	nodeTaintEffects       = []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}
+	nodeTaintValueRegexp   = regexp.MustCompile(`^[^:]*:(` + strings.Join(nodeTaintEffects, "|") + `)$`)
+	localStorageStrategies = []string{"RAID0", "RAID10", "Mount"}
+)
+
+func eksBottlerocketSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{FieldNodeConfigurationEKS + ".0." + FieldEKSAL2023},
+		Description: "Experimental. Bottlerocket settings rendered to TOML user data of the node. The rendered user data doesn't " +
+			"contain `settings.kubernetes` bootstrap settings (`api-server`, `cluster-certificate`, `cluster-name`), nodes rely on " +
+			"CAST AI adding them when provisioning the node. Can be used only with bottlerocket images and can't be combined with init scripts",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				FieldBottlerocketAdminContainerEnabled: {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether the admin host container is enabled. Defaults to false",
+				},
+				FieldBottlerocketControlContainerEnabled: {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Whether the control host container is enabled. Defaults to true",
+				},
+				FieldBottlerocketMaxPods: {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+					Description:      "Maximum number of pods that can run on the node",
+				},
+				FieldBottlerocketNodeLabels: {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Labels to be added on the node",
+				},
+				FieldBottlerocketNodeTaints: {
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+					ValidateDiagFunc: validation.MapValueMatch(
+						nodeTaintValueRegexp, "must be in format value:effect, where effect is one of: "+strings.Join(nodeTaintEffects, ", "),
+					),
+					Description: "Taints to be added on the node in format `key = \"value:effect\"`",
+				},
+				FieldBottlerocketKernelSysctl: {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Kernel parameters to be set on the node, e.g. `\"net.core.somaxconn\" = \"1024\"`",
+				},
+				FieldBottlerocketSettings: {
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Additional settings in TOML format merged into the rendered user data. Settings already set by other " +
+						"attributes of the block can't be set again. [Available values](https://bottlerocket.dev/en/os/latest/#/api/settings/)",
+				},
+			},
+		},
+	}
+}
+
+func eksAL2023Schema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{FieldNodeConfigurationEKS + ".0." + FieldEKSBottlerocket},
+		Description: "Experimental. nodeadm settings rendered to `NodeConfig` part of the node's user data. The rendered `NodeConfig` doesn't " +
+			"contain cluster settings (`name`, `apiServerEndpoint`, `certificateAuthority`), nodes rely on CAST AI adding them when " +
+			"provisioning the node. Can be used only with al2023 images. Init scripts are added as separate parts of the user data",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				FieldAL2023KubeletFlags: {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Additional command line flags passed to kubelet, e.g. `--node-labels=team=data`",
+				},
+				FieldAL2023LocalStorageStrategy: {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(localStorageStrategies, false)),
+					Description:      "Strategy of configuring instance store volumes. One of: " + strings.Join(localStorageStrategies, ", "),
+				},
+				FieldAL2023ContainerdConfig: {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Additional containerd configuration in TOML format merged with the default one",
+				},
+			},
+		},
+	}
+}
+
+// eksUserDataSettings returns configured bottlerocket and al2023 settings from the eks block.
+func eksUserDataSettings(eks interface{}) (bottlerocket, al2023 map[string]interface{}) {
+	items, ok := eks.([]interface{})
+	if !ok || len(items) == 0 {
+		return nil, nil
+	}
+	obj, ok := items[0].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	if v, ok := obj[FieldEKSBottlerocket].([]interface{}); ok && len(v) > 0 {
+		bottlerocket, _ = v[0].(map[string]interface{})
+		if bottlerocket == nil {
+			bottlerocket = map[string]interface{}{}
+		}
+	}
+	if v, ok := obj[FieldEKSAL2023].([]interface{}); ok && len(v) > 0 {
+		al2023, _ = v[0].(map[string]interface{})
+		if al2023 == nil {
+			al2023 = map[string]interface{}{}
+		}
+	}
+	return bottlerocket, al2023
+}
+
+// renderBottlerocketSettings renders bottlerocket settings to TOML user data. Additional settings are merged into
+// the rendered tables, keys set by both are rejected as TOML doesn't allow defining a key twice.
+func renderBottlerocketSettings(obj map[string]interface{}) (string, error) {
+	admin, _ := obj[FieldBottlerocketAdminContainerEnabled].(bool)
+	control, ok := obj[FieldBottlerocketControlContainerEnabled].(bool)
+	if !ok {
+		control = true
+	}
+	settings := map[string]interface{}{
+		"host-containers": map[string]interface{}{
+			"admin":   map[string]interface{}{"enabled": admin},
+			"control": map[string]interface{}{"enabled": control},
+		},
+	}
+
+	kubernetes := map[string]interface{}{}
+	if v, ok := obj[FieldBottlerocketMaxPods].(int); ok && v > 0 {
+		kubernetes["max-pods"] = v
+	}
+	if v, ok := obj[FieldBottlerocketNodeLabels].(map[string]interface{}); ok && len(v) > 0 {
+		kubernetes["node-labels"] = v
+	}
+	if v, ok := obj[FieldBottlerocketNodeTaints].(map[string]interface{}); ok && len(v) > 0 {
+		kubernetes["node-taints"] = v
+	}
+	if len(kubernetes) > 0 {
+		settings["kubernetes"] = kubernetes
+	}
+	if v, ok := obj[FieldBottlerocketKernelSysctl].(map[string]interface{}); ok && len(v) > 0 {
+		settings["kernel"] = map[string]interface{}{"sysctl": v}
+	}
+	userData := map[string]interface{}{"settings": settings}
+
+	if v, ok := obj[FieldBottlerocketSettings].(string); ok && strings.TrimSpace(v) != "" {
+		var additional map[string]interface{}
+		if _, err := toml.Decode(v, &additional); err != nil {
+			return "", fmt.Errorf("parsing %s: %w", FieldBottlerocketSettings, err)
+		}
+		if err := mergeTOMLTables(userData, additional, ""); err != nil {
+			return "", fmt.Errorf("merging %s: %w", FieldBottlerocketSettings, err)
+		}
+	}
+
+	var b strings.Builder
+	enc := toml.NewEncoder(&b)
+	enc.Indent = ""
+	if err := enc.Encode(userData); err != nil {
+		return "", fmt.Errorf("encoding bottlerocket settings: %w", err)
+	}
+	return b.String(), nil
+}
+
+// mergeTOMLTables merges src into dst, descending into tables defined by both.
+func mergeTOMLTables(dst, src map[string]interface{}, path string) error {
+	for k, v := range src {
+		key := strings.TrimPrefix(path+"."+k, ".")
+		existing, found := dst[k]
+		if !found {
+			dst[k] = v
+			continue
+		}
+
+		dstTable, dstIsTable := existing.(map[string]interface{})
+		srcTable, srcIsTable := v.(map[string]interface{})
+		if !dstIsTable || !srcIsTable {
+			return fmt.Errorf("%s is already set by other bottlerocket settings", key)
+		}
+		if err := mergeTOMLTables(dstTable, srcTable, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderNodeadmConfig renders al2023 settings to nodeadm NodeConfig document.
+func renderNodeadmConfig(obj map[string]interface{}) string {
+	var b strings.Builder
+	b.WriteString("apiVersion: node.eks.aws/v1alpha1\nkind: NodeConfig\nspec:")
+
+	containerd, _ := obj[FieldAL2023ContainerdConfig].(string)
+	strategy, _ := obj[FieldAL2023LocalStorageStrategy].(string)
+	flags, _ := obj[FieldAL2023KubeletFlags].([]interface{})
+	if containerd == "" && strategy == "" && len(flags) == 0 {
+		b.WriteString(" {}\n")
+		return b.String()
+	}
+	b.WriteString("\n")
+
+	// Strings are double quoted, which makes Go escapes valid YAML.
+	if containerd != "" {
+		fmt.Fprintf(&b, "  containerd:\n    config: %s\n", strconv.Quote(containerd))
+	}
+	if strategy != "" {
+		fmt.Fprintf(&b, "  instance:\n    localStorage:\n      strategy: %s\n", strategy)
+	}
+	if len(flags) > 0 {
+		b.WriteString("  kubelet:\n    flags:\n")
+		for _, f := range flags {
+			fmt.Fprintf(&b, "      - %s\n", strconv.Quote(fmt.Sprint(f)))
+		}
+	}
+	return b.String()
+}
+
+// composeEKSUserData builds user data from bottlerocket or al2023 settings combined with init scripts.
+// The result is sent as the init script, which the API only describes as run on the instance at launch. Rendering
+// settings into it relies on CAST AI passing it to the instance as its user data unchanged, which is where bottlerocket
+// reads TOML settings and nodeadm reads NodeConfig parts of a multipart document.
+func composeEKSUserData(bottlerocket, al2023 map[string]interface{}, text string, parts []interface{}, vars map[string]string) (string, error) {
+	if bottlerocket != nil {
+		if text != "" || len(parts) > 0 {
+			return "", fmt.Errorf("bottlerocket settings can't be combined with init scripts")
+		}
+		return renderBottlerocketSettings(bottlerocket)
+	}
+
+	rendered := []initScriptPart{{content: renderNodeadmConfig(al2023), contentType: nodeadmContentType}}
+	if text != "" {
+		rendered = append(rendered, initScriptPart{content: renderInitScript(text, vars), contentType: initScriptPartContentTypes[0]})
+	}
+	for _, p := range toInitScriptParts(parts) {
+		p.content = renderInitScript(p.content, vars)
+		rendered = append(rendered, p)
+	}
+	return composeMultipartInitScript(rendered)
+}
+
+// imageFamily returns family selected with image selector, or empty string when the image is given directly,
+// as family of an image ID can't be known without looking the image up.
+func imageFamily(selector []interface{}) string {
+	if len(selector) == 0 {
+		return ""
+	}
+	obj, _ := selector[0].(map[string]interface{})
+	return toImageSelector(obj).family
+}
+
+// nodeConfigurationEKSUserDataDiff checks that bottlerocket and al2023 settings match the family of image selector.
+func nodeConfigurationEKSUserDataDiff(diff *schema.ResourceDiff) error {
+	if !diff.NewValueKnown(FieldNodeConfigurationEKS) {
+		return nil
+	}
+	bottlerocket, al2023 := eksUserDataSettings(diff.Get(FieldNodeConfigurationEKS))
+	if bottlerocket == nil && al2023 == nil {
+		return nil
+	}
+
+	if bottlerocket != nil && diff.NewValueKnown(FieldNodeConfigurationInitScript) && diff.Get(FieldNodeConfigurationInitScript).(string) != "" {
+		return fmt.Errorf("eks.0.%s can't be combined with %s", FieldEKSBottlerocket, FieldNodeConfigurationInitScript)
+	}
+	if al2023 != nil && diff.NewValueKnown(FieldNodeConfigurationInitScript) && diff.Get(FieldNodeConfigurationInitScript).(string) != "" {
+		return fmt.Errorf("eks.0.%s can't be combined with %s, use %s or %s instead", FieldEKSAL2023, FieldNodeConfigurationInitScript,
+			FieldNodeConfigurationInitScriptText, FieldNodeConfigurationInitScriptPart)
+	}
+
+	if !diff.NewValueKnown(FieldNodeConfigurationImageSelector) {
+		return nil
+	}
+	family := imageFamily(diff.Get(FieldNodeConfigurationImageSelector).([]interface{}))
+	if family == "" {
+		return nil
+	}
+	if bottlerocket != nil && family != ImageFamilyBottlerocket {
+		return fmt.Errorf("eks.0.%s requires %s image, but %s image is selected", FieldEKSBottlerocket, ImageFamilyBottlerocket, family)
+	}
+	if al2023 != nil && family != ImageFamilyAL2023 {
+		return fmt.Errorf("eks.0.%s requires %s image, but %s image is selected", FieldEKSAL2023, ImageFamilyAL2023, family)
+	}
+	return nil
+}
+
+// keepEKSUserDataSettings copies bottlerocket and al2023 settings from the configuration, as API only returns rendered user data.
+func keepEKSUserDataSettings(d *schema.ResourceData, eks []map[string]interface{}) {
+	if len(eks) == 0 {
+		return
+	}
+	items, ok := d.Get(FieldNodeConfigurationEKS).([]interface{})
+	if !ok || len(items) == 0 {
+		return
+	}
+	obj, ok := items[0].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, k := range []string{FieldEKSBottlerocket, FieldEKSAL2023} {
+		if v, ok := obj[k].([]interface{}); ok && len(v) > 0 {
+			eks[0][k] = v
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package castai
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+func TestRenderBottlerocketSettings(t *testing.T) {
+	r := require.New(t)
+
+	actual, err := renderBottlerocketSettings(map[string]interface{}{
+		FieldBottlerocketAdminContainerEnabled:   true,
+		FieldBottlerocketControlContainerEnabled: false,
+		FieldBottlerocketMaxPods:                 58,
+		FieldBottlerocketNodeLabels: map[string]interface{}{
+			"team":                    "data",
+			"node.kubernetes.io/role": "worker",
+		},
+		FieldBottlerocketNodeTaints: map[string]interface{}{
+			"dedicated": "data:NoSchedule",
+		},
+		FieldBottlerocketKernelSysctl: map[string]interface{}{
+			"net.core.somaxconn": "1024",
+		},
+		FieldBottlerocketSettings: "[settings.kubernetes]\ncluster-dns-ip = \"10.100.0.10\"\n\n" +
+			"[settings.kubernetes.eviction-hard]\n\"memory.available\" = \"15%\"\n",
+	})
+	r.NoError(err)
+
+	r.Equal(`[settings]
+[settings.host-containers]
+[settings.host-containers.admin]
+enabled = true
+[settings.host-containers.control]
+enabled = false
+[settings.kernel]
+[settings.kernel.sysctl]
+"net.core.somaxconn" = "1024"
+[settings.kubernetes]
+cluster-dns-ip = "10.100.0.10"
+max-pods = 58
+[settings.kubernetes.eviction-hard]
+"memory.available" = "15%"
+[settings.kubernetes.node-labels]
+"node.kubernetes.io/role" = "worker"
+team = "data"
+[settings.kubernetes.node-taints]
+dedicated = "data:NoSchedule"
+`, actual)
+}
+
+func TestRenderBottlerocketSettings_errors(t *testing.T) {
+	tt := map[string]struct {
+		settings string
+		errMsg   string
+	}{
+		"key set by other settings": {
+			settings: "[settings.kubernetes]\nmax-pods = 110\n",
+			errMsg:   "merging settings: settings.kubernetes.max-pods is already set by other bottlerocket settings",
+		},
+		"table set as value": {
+			settings: "[settings]\nhost-containers = \"none\"\n",
+			errMsg:   "merging settings: settings.host-containers is already set by other bottlerocket settings",
+		},
+		"invalid TOML": {
+			settings: "[settings.kubernetes\n",
+			errMsg:   "parsing settings:",
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+
+			_, err := renderBottlerocketSettings(map[string]interface{}{
+				FieldBottlerocketMaxPods:  58,
+				FieldBottlerocketSettings: tc.settings,
+			})
+			r.Error(err)
+			r.Contains(err.Error(), tc.errMsg)
+		})
+	}
+}
+
+func TestComposeEKSUserData_al2023(t *testing.T) {
+	r := require.New(t)
+
+	actual, err := composeEKSUserData(nil, map[string]interface{}{
+		FieldAL2023KubeletFlags:         []interface{}{"--node-labels=team=data"},
+		FieldAL2023LocalStorageStrategy: "RAID0",
+		FieldAL2023ContainerdConfig:     "[plugins.\"io.containerd.grpc.v1.cri\"]\nsandbox_image = \"pause:3.9\"\n",
+	}, "#!/bin/bash\necho ${configuration_name}\n", nil, map[string]string{InitScriptVarConfigurationName: "default"})
+	r.NoError(err)
+
+	expected := "MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/mixed; boundary=\"==CASTAI-INIT-SCRIPT-BOUNDARY==\"\r\n" +
+		"\r\n" +
+		"--==CASTAI-INIT-SCRIPT-BOUNDARY==\r\n" +
+		"Content-Transfer-Encoding: 7bit\r\n" +
+		"Content-Type: application/node.eks.aws; charset=\"us-ascii\"\r\n" +
+		"Mime-Version: 1.0\r\n" +
+		"\r\n" +
+		"apiVersion: node.eks.aws/v1alpha1\n" +
+		"kind: NodeConfig\n" +
+		"spec:\n" +
+		"  containerd:\n" +
+		"    config: \"[plugins.\\\"io.containerd.grpc.v1.cri\\\"]\\nsandbox_image = \\\"pause:3.9\\\"\\n\"\n" +
+		"  instance:\n" +
+		"    localStorage:\n" +
+		"      strategy: RAID0\n" +
+		"  kubelet:\n" +
+		"    flags:\n" +
+		"      - \"--node-labels=team=data\"\n" +
+		"\r\n" +
+		"--==CASTAI-INIT-SCRIPT-BOUNDARY==\r\n" +
+		"Content-Transfer-Encoding: 7bit\r\n" +
+		"Content-Type: text/x-shellscript; charset=\"us-ascii\"\r\n" +
+		"Mime-Version: 1.0\r\n" +
+		"\r\n" +
+		"#!/bin/bash\necho default\n" +
+		"\r\n" +
+		"--==CASTAI-INIT-SCRIPT-BOUNDARY==--\r\n"
+	r.Equal(expected, actual)
+}
+
+func TestComposeEKSUserData_bottlerocketWithInitScript(t *testing.T) {
+	r := require.New(t)
+
+	_, err := composeEKSUserData(map[string]interface{}{}, nil, "#!/bin/bash\n", nil, nil)
+	r.EqualError(err, "bottlerocket settings can't be combined with init scripts")
+}
+
+func TestNodeConfigurationResourceCustomizeDiff_eksUserData(t *testing.T) {
+	eksWithSettings := func(block string, settings map[string]cty.Value) cty.Value {
+		attrs := map[string]cty.Value{
+			"instance_profile_arn": cty.StringVal("arn:aws:iam::123456789012:instance-profile/castai-eks-instance"),
+			"security_groups":      cty.ListVal([]cty.Value{cty.StringVal("sg-0123456789")}),
+		}
+		attrs[block] = cty.ListVal([]cty.Value{cty.ObjectVal(settings)})
+		return cty.ListVal([]cty.Value{cty.ObjectVal(attrs)})
+	}
+	eks := func(block string) cty.Value {
+		return eksWithSettings(block, map[string]cty.Value{})
+	}
+
+	tt := map[string]struct {
+		attributes map[string]cty.Value
+		errMsg     string
+	}{
+		"bottlerocket with bottlerocket image": {
+			attributes: map[string]cty.Value{
+				FieldNodeConfigurationEKS: eks(FieldEKSBottlerocket),
+				FieldNodeConfigurationImageSelector: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					FieldImageSelectorFamily:            cty.StringVal(ImageFamilyBottlerocket),
+					FieldImageSelectorKubernetesVersion: cty.StringVal("1.29"),
+				})}),
+			},
+		},
+		"bottlerocket with image ID": {
+			attributes: map[string]cty.Value{
+				FieldNodeConfigurationEKS:   eks(FieldEKSBottlerocket),
+				FieldNodeConfigurationImage: cty.StringVal("ami-0123456789"),
+			},
+		},
+		"bottlerocket with image name of other family": {
+			attributes: map[string]cty.Value{
+				FieldNodeConfigurationEKS:   eks(FieldEKSBottlerocket),
+				FieldNodeConfigurationImage: cty.StringVal("amazon-eks-node-1.29-v20240514"),
+			},
+		},
+		"bottlerocket settings set twice": {
+			attributes: map[string]cty.Value{
+				FieldNodeConfigurationEKS: eksWithSettings(FieldEKSBottlerocket, map[string]cty.Value{
+					FieldBottlerocketMaxPods:  cty.NumberIntVal(58),
+					FieldBottlerocketSettings: cty.StringVal("[settings.kubernetes]\nmax-pods = 110\n"),
+				}),
+			},
+			errMsg: "composing init script: merging settings: settings.kubernetes.max-pods is already set by other bottlerocket settings",
+		},
+		"bottlerocket with al2 image": {
+			attributes: map[string]cty.Value{
+				FieldNodeConfigurationEKS: eks(FieldEKSBottlerocket),
+				FieldNodeConfigurationImageSelector: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					FieldImageSelectorFamily:            cty.StringVal(ImageFamilyAL2),
+					FieldImageSelectorKubernetesVersion: cty.StringVal("1.29"),
+				})}),
+			},
+			errMsg: "eks.0.bottlerocket requires bottlerocket image, but al2 image is selected",
+		},
+		"al2023 with bottlerocket image": {
+			attributes: map[string]cty.Value{
+				FieldNodeConfigurationEKS: eks(FieldEKSAL2023),
+				FieldNodeConfigurationImageSelector: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					FieldImageSelectorFamily:            cty.StringVal(ImageFamilyBottlerocket),
+					FieldImageSelectorKubernetesVersion: cty.StringVal("1.29"),
+				})}),
+			},
+			errMsg: "eks.0.al2023 requires al2023 image, but bottlerocket image is selected",
+		},
+		"al2023 with base64 encoded init script": {
+			attributes: map[string]cty.Value{
+				FieldNodeConfigurationEKS:        eks(FieldEKSAL2023),
+				FieldNodeConfigurationInitScript: cty.StringVal(base64.StdEncoding.EncodeToString([]byte("#!/bin/bash\n"))),
+			},
+			errMsg: "eks.0.al2023 can't be combined with init_script",
+		},
+		"bottlerocket with plain text init script": {
+			attributes: map[string]cty.Value{
+				FieldNodeConfigurationEKS:            eks(FieldEKSBottlerocket),
+				FieldNodeConfigurationInitScriptText: cty.StringVal("#!/bin/bash\n"),
+			},
+			errMsg: "bottlerocket settings can't be combined with init scripts",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			resource := resourceNodeConfiguration()
+
+			attributes := map[string]cty.Value{
+				FieldClusterID:                cty.StringVal("b6bfc074-a267-400f-b8f1-db0850c369b1"),
+				FieldNodeConfigurationName:    cty.StringVal("default"),
+				FieldNodeConfigurationSubnets: cty.ListVal([]cty.Value{cty.StringVal("subnet-1")}),
+			}
+			for k, v := range tc.attributes {
+				attributes[k] = v
+			}
+			val := cty.ObjectVal(attributes)
+			state := &terraform.InstanceState{RawConfig: val}
+			config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+			provider := newClusterProvider(t, "b6bfc074-a267-400f-b8f1-db0850c369b1", `{"eks": {"region": "eu-central-1"}}`)
+			provider.imageResolver = &fakeImageResolver{}
+			_, err := resource.Diff(context.Background(), state, config, provider)
+			if tc.errMsg == "" {
+				r.NoError(err)
+				return
+			}
+			r.Error(err)
+			r.Contains(err.Error(), tc.errMsg)
+		})
+	}
+}
+
+func TestNodeConfigurationResourceCreate_bottlerocket(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterID := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	configID := "c0e6d9ac-dbe6-4a8f-8a2e-92e7a7b9a1f5"
+	expectedScript := base64.StdEncoding.EncodeToString([]byte("[settings]\n[settings.host-containers]\n[settings.host-containers.admin]\nenabled = false\n" +
+		"[settings.host-containers.control]\nenabled = true\n[settings.kubernetes]\nmax-pods = 58\n"))
+	body := func() io.ReadCloser {
+		return io.NopCloser(bytes.NewReader([]byte(`{
+		  "id": "` + configID + `",
+		  "name": "default",
+		  "subnets": ["subnet-1"],
+		  "tags": {},
+		  "initScript": "` + expectedScript + `",
+		  "eks": {
+		    "instanceProfileArn": "arn:aws:iam::123456789012:instance-profile/castai-eks-instance",
+		    "securityGroups": ["sg-0123456789"]
+		  }
+		}`)))
+	}
+
+	mockClient.EXPECT().
+		NodeConfigurationAPICreateConfiguration(gomock.Any(), clusterID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, req sdk.NodeConfigurationAPICreateConfigurationJSONRequestBody) (*http.Response, error) {
+			r.Equal(expectedScript, *req.InitScript)
+			return &http.Response{StatusCode: 200, Body: body(), Header: map[string][]string{"Content-Type": {"json"}}}, nil
+		})
+	mockClient.EXPECT().
+		NodeConfigurationAPIGetConfiguration(gomock.Any(), clusterID, configID).
+		Return(&http.Response{StatusCode: 200, Body: body(), Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+
+	resource := resourceNodeConfiguration()
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterID:                cty.StringVal(clusterID),
+		FieldNodeConfigurationName:    cty.StringVal("default"),
+		FieldNodeConfigurationSubnets: cty.ListVal([]cty.Value{cty.StringVal("subnet-1")}),
+		FieldNodeConfigurationEKS: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"instance_profile_arn": cty.StringVal("arn:aws:iam::123456789012:instance-profile/castai-eks-instance"),
+			"security_groups":      cty.ListVal([]cty.Value{cty.StringVal("sg-0123456789")}),
+			FieldEKSBottlerocket: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				FieldBottlerocketControlContainerEnabled: cty.True,
+				FieldBottlerocketMaxPods:                 cty.NumberIntVal(58),
+			})}),
+		})}),
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+
+	data := resource.Data(state)
+	result := resource.CreateContext(context.Background(), data, provider)
+	r.Nil(result)
+	r.Equal(configID, data.Id())
+	r.Equal(58, data.Get("eks.0.bottlerocket.0.max_pods"))
+	r.Empty(data.Get(FieldNodeConfigurationInitScript))
+}
@@ -0,0 +1,254 @@
+package castai
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+)
+
+const (
+	FieldImageSelectorFamily            = "family"
+	FieldImageSelectorArchitecture      = "architecture"
+	FieldImageSelectorVersion           = "version"
+	FieldImageSelectorKubernetesVersion = "kubernetes_version"
+
+	ImageFamilyAL2          = "al2"
+	ImageFamilyAL2023       = "al2023"
+	ImageFamilyBottlerocket = "bottlerocket"
+)
+
+var (
+	imageFamilies                = []string{ImageFamilyAL2, ImageFamilyAL2023, ImageFamilyBottlerocket}
+	kubernetesMinorVersionRegexp = regexp.MustCompile(`^[0-9]+\.[0-9]+$`)
+)
+
+func imageSelectorSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{FieldNodeConfigurationImage},
+		Description: "Selects the image by family instead of providing it in `image`. The image is resolved at plan time using public AWS SSM parameters, " +
+			"so a newer image of the family shows up as a diff of `resolved_image`. Requires `aws` block in the provider configuration " +
+			"with credentials allowing `ssm:GetParameter`. Applicable for EKS only",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				FieldImageSelectorFamily: {
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(imageFamilies, false)),
+					Description:      "Image family. One of: " + strings.Join(imageFamilies, ", "),
+				},
+				FieldImageSelectorArchitecture: {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          ArchAMD64,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{ArchAMD64, ArchARM64}, false)),
+					Description:      "Image architecture. One of: amd64, arm64. Defaults to amd64",
+				},
+				FieldImageSelectorVersion: {
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Release of the image family to pin to, e.g. `20240514` for al2 and al2023 or `1.19.2` for bottlerocket. " +
+						"Latest release is used when not provided",
+				},
+				FieldImageSelectorKubernetesVersion: {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(kubernetesMinorVersionRegexp, "must be a Kubernetes minor version, e.g. 1.29")),
+					Description:      "Kubernetes version of the image, e.g. `1.29`. Defaults to the version of the cluster",
+				},
+			},
+		},
+	}
+}
+
+type imageSelector struct {
+	family            string
+	architecture      string
+	version           string
+	kubernetesVersion string
+}
+
+// imageResolver resolves image selector to a concrete image.
+type imageResolver interface {
+	ResolveImage(ctx context.Context, region string, selector imageSelector) (string, error)
+}
+
+// ssmImageResolver resolves EKS optimized images using public SSM parameters published by AWS.
+type ssmImageResolver struct {
+	credentials *credentials.Credentials
+}
+
+// newSSMImageResolver returns resolver using only the given static credentials.
+func newSSMImageResolver(accessKey, secretKey, sessionToken string) *ssmImageResolver {
+	return &ssmImageResolver{credentials: credentials.NewStaticCredentials(accessKey, secretKey, sessionToken)}
+}
+
+func (r *ssmImageResolver) ResolveImage(ctx context.Context, region string, selector imageSelector) (string, error) {
+	name, err := imageSSMParameterName(selector)
+	if err != nil {
+		return "", err
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            aws.Config{Region: aws.String(region), Credentials: r.credentials},
+		SharedConfigState: session.SharedConfigDisable,
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating AWS session: %w", err)
+	}
+
+	out, err := ssm.New(sess).GetParameterWithContext(ctx, &ssm.GetParameterInput{Name: aws.String(name)})
+	if err != nil {
+		return "", fmt.Errorf("getting SSM parameter %q: %w", name, err)
+	}
+	return aws.StringValue(out.Parameter.Value), nil
+}
+
+// imageSSMParameterName returns name of the public SSM parameter holding image ID of the selected image.
+func imageSSMParameterName(s imageSelector) (string, error) {
+	arch := "x86_64"
+	if s.architecture == ArchARM64 {
+		arch = "arm64"
+	}
+	version := strings.TrimPrefix(s.version, "v")
+	k8s := s.kubernetesVersion
+
+	switch s.family {
+	case ImageFamilyAL2:
+		path, node := "amazon-linux-2", "amazon-eks-node"
+		if s.architecture == ArchARM64 {
+			path, node = "amazon-linux-2-arm64", "amazon-eks-arm64-node"
+		}
+		if version == "" {
+			return fmt.Sprintf("/aws/service/eks/optimized-ami/%s/%s/recommended/image_id", k8s, path), nil
+		}
+		return fmt.Sprintf("/aws/service/eks/optimized-ami/%s/%s/%s-%s-v%s/image_id", k8s, path, node, k8s, version), nil
+	case ImageFamilyAL2023:
+		if version == "" {
+			return fmt.Sprintf("/aws/service/eks/optimized-ami/%s/amazon-linux-2023/%s/standard/recommended/image_id", k8s, arch), nil
+		}
+		return fmt.Sprintf("/aws/service/eks/optimized-ami/%s/amazon-linux-2023/%s/standard/amazon-eks-node-al2023-%s-standard-%s-v%s/image_id", k8s, arch, arch, k8s, version), nil
+	case ImageFamilyBottlerocket:
+		if version == "" {
+			version = "latest"
+		}
+		return fmt.Sprintf("/aws/service/bottlerocket/aws-k8s-%s/%s/%s/image_id", k8s, arch, version), nil
+	}
+	return "", fmt.Errorf("unsupported image family %q", s.family)
+}
+
+func toImageSelector(obj map[string]interface{}) imageSelector {
+	s := imageSelector{architecture: ArchAMD64}
+	if v, ok := obj[FieldImageSelectorFamily].(string); ok {
+		s.family = v
+	}
+	if v, ok := obj[FieldImageSelectorArchitecture].(string); ok && v != "" {
+		s.architecture = v
+	}
+	if v, ok := obj[FieldImageSelectorVersion].(string); ok {
+		s.version = v
+	}
+	if v, ok := obj[FieldImageSelectorKubernetesVersion].(string); ok {
+		s.kubernetesVersion = v
+	}
+	return s
+}
+
+// resolveImage resolves image selector using region and Kubernetes version of the cluster.
+func resolveImage(ctx context.Context, meta interface{}, clusterID string, selector imageSelector) (string, error) {
+	config := meta.(*ProviderConfig)
+	if config.imageResolver == nil {
+		return "", fmt.Errorf("image selector requires %s block in the provider configuration", FieldProviderAWS)
+	}
+
+	cluster, err := getCluster(ctx, config.api, clusterID)
+	if err != nil {
+		return "", err
+	}
+
+	if cluster.Eks == nil {
+		return "", fmt.Errorf("image selector is supported for EKS clusters only")
+	}
+	if selector.kubernetesVersion == "" {
+		selector.kubernetesVersion = kubernetesMinorVersion(lo.FromPtr(cluster.KubernetesVersion))
+	}
+	if selector.kubernetesVersion == "" {
+		return "", fmt.Errorf("kubernetes version of cluster %q is not known yet, provide it in image selector", clusterID)
+	}
+
+	image, err := config.imageResolver.ResolveImage(ctx, lo.FromPtr(cluster.Eks.Region), selector)
+	if err != nil {
+		return "", fmt.Errorf("resolving %s image: %w", selector.family, err)
+	}
+	return image, nil
+}
+
+// kubernetesMinorVersion trims patch version and build metadata, e.g. 1.27.3-eks-a5565ad becomes 1.27.
+func kubernetesMinorVersion(v string) string {
+	v = strings.TrimPrefix(v, "v")
+	parts := strings.SplitN(v, ".", 3)
+	if len(parts) < 2 {
+		return v
+	}
+	minor := strings.FieldsFunc(parts[1], func(r rune) bool { return r < '0' || r > '9' })
+	if len(minor) == 0 {
+		return v
+	}
+	return parts[0] + "." + minor[0]
+}
+
+// imageFromResourceData returns image to be used by the node configuration, resolving image selector if needed.
+func imageFromResourceData(ctx context.Context, d *schema.ResourceData, meta interface{}) (string, error) {
+	v, ok := d.GetOk(FieldNodeConfigurationImageSelector)
+	if !ok || len(v.([]interface{})) == 0 {
+		return d.Get(FieldNodeConfigurationImage).(string), nil
+	}
+	if image := d.Get(FieldNodeConfigurationResolvedImage).(string); image != "" {
+		return image, nil
+	}
+
+	obj, _ := v.([]interface{})[0].(map[string]interface{})
+	image, err := resolveImage(ctx, meta, d.Get(FieldClusterID).(string), toImageSelector(obj))
+	if err != nil {
+		return "", err
+	}
+	if err := d.Set(FieldNodeConfigurationResolvedImage, image); err != nil {
+		return "", fmt.Errorf("setting resolved image: %w", err)
+	}
+	return image, nil
+}
+
+// nodeConfigurationImageDiff resolves image selector at plan time, so newer images show up as a diff.
+func nodeConfigurationImageDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	v, ok := diff.GetOk(FieldNodeConfigurationImageSelector)
+	if !ok || len(v.([]interface{})) == 0 {
+		if old, _ := diff.GetChange(FieldNodeConfigurationResolvedImage); old.(string) != "" {
+			return diff.SetNew(FieldNodeConfigurationResolvedImage, "")
+		}
+		return nil
+	}
+	if !diff.NewValueKnown(FieldClusterID) || !diff.NewValueKnown(FieldNodeConfigurationImageSelector) {
+		return diff.SetNewComputed(FieldNodeConfigurationResolvedImage)
+	}
+
+	obj, _ := v.([]interface{})[0].(map[string]interface{})
+	image, err := resolveImage(ctx, meta, diff.Get(FieldClusterID).(string), toImageSelector(obj))
+	if err != nil {
+		return err
+	}
+	if old, _ := diff.GetChange(FieldNodeConfigurationResolvedImage); old.(string) != image {
+		return diff.SetNew(FieldNodeConfigurationResolvedImage, image)
+	}
+	return nil
+}
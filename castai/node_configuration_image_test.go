@@ -0,0 +1,303 @@
+package castai
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+type fakeImageResolver struct {
+	images map[string]string
+}
+
+func (f *fakeImageResolver) ResolveImage(_ context.Context, region string, selector imageSelector) (string, error) {
+	name, err := imageSSMParameterName(selector)
+	if err != nil {
+		return "", err
+	}
+	return f.images[region+":"+name], nil
+}
+
+func TestImageSSMParameterName(t *testing.T) {
+	tt := map[string]struct {
+		selector imageSelector
+		expected string
+	}{
+		"al2 latest": {
+			selector: imageSelector{family: ImageFamilyAL2, architecture: ArchAMD64, kubernetesVersion: "1.29"},
+			expected: "/aws/service/eks/optimized-ami/1.29/amazon-linux-2/recommended/image_id",
+		},
+		"al2 arm64 pinned": {
+			selector: imageSelector{family: ImageFamilyAL2, architecture: ArchARM64, version: "v20240514", kubernetesVersion: "1.29"},
+			expected: "/aws/service/eks/optimized-ami/1.29/amazon-linux-2-arm64/amazon-eks-arm64-node-1.29-v20240514/image_id",
+		},
+		"al2023 latest": {
+			selector: imageSelector{family: ImageFamilyAL2023, architecture: ArchARM64, kubernetesVersion: "1.30"},
+			expected: "/aws/service/eks/optimized-ami/1.30/amazon-linux-2023/arm64/standard/recommended/image_id",
+		},
+		"al2023 pinned": {
+			selector: imageSelector{family: ImageFamilyAL2023, architecture: ArchAMD64, version: "20240514", kubernetesVersion: "1.30"},
+			expected: "/aws/service/eks/optimized-ami/1.30/amazon-linux-2023/x86_64/standard/amazon-eks-node-al2023-x86_64-standard-1.30-v20240514/image_id",
+		},
+		"bottlerocket latest": {
+			selector: imageSelector{family: ImageFamilyBottlerocket, architecture: ArchAMD64, kubernetesVersion: "1.29"},
+			expected: "/aws/service/bottlerocket/aws-k8s-1.29/x86_64/latest/image_id",
+		},
+		"bottlerocket pinned": {
+			selector: imageSelector{family: ImageFamilyBottlerocket, architecture: ArchARM64, version: "1.19.2", kubernetesVersion: "1.29"},
+			expected: "/aws/service/bottlerocket/aws-k8s-1.29/arm64/1.19.2/image_id",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			actual, err := imageSSMParameterName(tc.selector)
+			r.NoError(err)
+			r.Equal(tc.expected, actual)
+		})
+	}
+}
+
+func TestKubernetesMinorVersion(t *testing.T) {
+	r := require.New(t)
+
+	r.Equal("1.27", kubernetesMinorVersion("1.27"))
+	r.Equal("1.27", kubernetesMinorVersion("1.27.3"))
+	r.Equal("1.27", kubernetesMinorVersion("v1.27.3-eks-a5565ad"))
+	r.Equal("1.27", kubernetesMinorVersion("1.27+"))
+	r.Equal("", kubernetesMinorVersion(""))
+}
+
+func TestNodeConfigurationResourceCustomizeDiff_imageSelector(t *testing.T) {
+	clusterID := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	latest := "/aws/service/bottlerocket/aws-k8s-1.29/x86_64/latest/image_id"
+
+	tt := map[string]struct {
+		resolvedImage string
+		latestImage   string
+		expectDiff    bool
+	}{
+		"resolves image on create": {
+			latestImage: "ami-0123456789",
+			expectDiff:  true,
+		},
+		"no diff when image is up to date": {
+			resolvedImage: "ami-0123456789",
+			latestImage:   "ami-0123456789",
+		},
+		"diff when newer image appears": {
+			resolvedImage: "ami-0123456789",
+			latestImage:   "ami-9876543210",
+			expectDiff:    true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+			provider := &ProviderConfig{
+				api: &sdk.ClientWithResponses{
+					ClientInterface: mockClient,
+				},
+				imageResolver: &fakeImageResolver{images: map[string]string{
+					"eu-central-1:" + latest: tc.latestImage,
+				}},
+			}
+
+			mockClient.EXPECT().
+				ExternalClusterAPIGetCluster(gomock.Any(), clusterID).
+				DoAndReturn(func(_ context.Context, _ string) (*http.Response, error) {
+					return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{
+					  "id": "b6bfc074-a267-400f-b8f1-db0850c369b1",
+					  "kubernetesVersion": "1.29.4-eks-036c24b",
+					  "eks": {"region": "eu-central-1"}
+					}`))), Header: map[string][]string{"Content-Type": {"json"}}}, nil
+				}).AnyTimes()
+
+			resource := resourceNodeConfiguration()
+			val := cty.ObjectVal(map[string]cty.Value{
+				FieldClusterID:                cty.StringVal(clusterID),
+				FieldNodeConfigurationName:    cty.StringVal("default"),
+				FieldNodeConfigurationSubnets: cty.ListVal([]cty.Value{cty.StringVal("subnet-1")}),
+				FieldNodeConfigurationImageSelector: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					FieldImageSelectorFamily: cty.StringVal(ImageFamilyBottlerocket),
+				})}),
+			})
+
+			state := &terraform.InstanceState{RawConfig: val}
+			if tc.resolvedImage != "" {
+				state = terraform.NewInstanceStateShimmedFromValue(val, 0)
+				state.ID = "c0e6d9ac-dbe6-4a8f-8a2e-92e7a7b9a1f5"
+				state.Attributes[FieldNodeConfigurationResolvedImage] = tc.resolvedImage
+				state.Attributes[FieldNodeConfigurationDiskCpuRatio] = "0"
+				state.Attributes[FieldNodeConfigurationMinDiskSize] = "100"
+				state.Attributes[FieldNodeConfigurationImageSelector+".0."+FieldImageSelectorArchitecture] = ArchAMD64
+				state.RawConfig = val
+			}
+			config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+			diff, err := resource.Diff(context.Background(), state, config, provider)
+			r.NoError(err)
+			if !tc.expectDiff {
+				r.Nil(diff)
+				return
+			}
+			r.NotNil(diff)
+			r.Equal(tc.latestImage, diff.Attributes[FieldNodeConfigurationResolvedImage].New)
+		})
+	}
+}
+
+func TestNodeConfigurationResourceCustomizeDiff_imageSelectorNonEKS(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+		imageResolver: &fakeImageResolver{},
+	}
+
+	clusterID := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	mockClient.EXPECT().
+		ExternalClusterAPIGetCluster(gomock.Any(), clusterID).
+		Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{
+		  "id": "b6bfc074-a267-400f-b8f1-db0850c369b1",
+		  "kubernetesVersion": "1.29",
+		  "gke": {"region": "europe-west1"}
+		}`))), Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+
+	resource := resourceNodeConfiguration()
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterID:                cty.StringVal(clusterID),
+		FieldNodeConfigurationName:    cty.StringVal("default"),
+		FieldNodeConfigurationSubnets: cty.ListVal([]cty.Value{cty.StringVal("subnet-1")}),
+		FieldNodeConfigurationImageSelector: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			FieldImageSelectorFamily: cty.StringVal(ImageFamilyAL2023),
+		})}),
+	})
+	state := &terraform.InstanceState{RawConfig: val}
+	config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+	_, err := resource.Diff(context.Background(), state, config, provider)
+	r.Error(err)
+	r.Contains(err.Error(), "image selector is supported for EKS clusters only")
+}
+
+func TestNodeConfigurationResourceCreate_imageSelector(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterID := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	configID := "c0e6d9ac-dbe6-4a8f-8a2e-92e7a7b9a1f5"
+	body := func() io.ReadCloser {
+		return io.NopCloser(bytes.NewReader([]byte(`{
+		  "id": "` + configID + `",
+		  "name": "default",
+		  "subnets": ["subnet-1"],
+		  "tags": {},
+		  "image": "ami-0123456789"
+		}`)))
+	}
+
+	mockClient.EXPECT().
+		NodeConfigurationAPICreateConfiguration(gomock.Any(), clusterID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, req sdk.NodeConfigurationAPICreateConfigurationJSONRequestBody) (*http.Response, error) {
+			r.Equal("ami-0123456789", *req.Image)
+			return &http.Response{StatusCode: 200, Body: body(), Header: map[string][]string{"Content-Type": {"json"}}}, nil
+		})
+	mockClient.EXPECT().
+		NodeConfigurationAPIGetConfiguration(gomock.Any(), clusterID, configID).
+		Return(&http.Response{StatusCode: 200, Body: body(), Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+
+	resource := resourceNodeConfiguration()
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterID:                      cty.StringVal(clusterID),
+		FieldNodeConfigurationName:          cty.StringVal("default"),
+		FieldNodeConfigurationSubnets:       cty.ListVal([]cty.Value{cty.StringVal("subnet-1")}),
+		FieldNodeConfigurationResolvedImage: cty.StringVal("ami-0123456789"),
+		FieldNodeConfigurationImageSelector: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			FieldImageSelectorFamily: cty.StringVal(ImageFamilyAL2023),
+		})}),
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+
+	data := resource.Data(state)
+	result := resource.CreateContext(context.Background(), data, provider)
+	r.Nil(result)
+	r.Equal("ami-0123456789", data.Get(FieldNodeConfigurationResolvedImage))
+	r.Empty(data.Get(FieldNodeConfigurationImage))
+}
+
+func TestNodeConfigurationResourceCustomizeDiff_imageSelectorWithoutAWSConfig(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	resource := resourceNodeConfiguration()
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterID:                cty.StringVal("b6bfc074-a267-400f-b8f1-db0850c369b1"),
+		FieldNodeConfigurationName:    cty.StringVal("default"),
+		FieldNodeConfigurationSubnets: cty.ListVal([]cty.Value{cty.StringVal("subnet-1")}),
+		FieldNodeConfigurationImageSelector: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			FieldImageSelectorFamily: cty.StringVal(ImageFamilyAL2023),
+		})}),
+	})
+	state := &terraform.InstanceState{RawConfig: val}
+	config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+	_, err := resource.Diff(context.Background(), state, config, provider)
+	r.Error(err)
+	r.Contains(err.Error(), "image selector requires aws block in the provider configuration")
+}
+
+func TestNewSSMImageResolver(t *testing.T) {
+	r := require.New(t)
+	t.Setenv("AWS_ACCESS_KEY_ID", "ambient-access-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "ambient-secret-key")
+
+	resolver := newSSMImageResolver("access-key", "secret-key", "")
+	value, err := resolver.credentials.Get()
+	r.NoError(err)
+	r.Equal("access-key", value.AccessKeyID)
+	r.Equal("secret-key", value.SecretAccessKey)
+	r.Equal("StaticProvider", value.ProviderName)
+}
+
+func TestToImageResolver(t *testing.T) {
+	r := require.New(t)
+	providerSchema := Provider("v1.0.0").Schema
+
+	data := schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{})
+	r.Nil(toImageResolver(data))
+
+	data = schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{
+		FieldProviderAWS: []interface{}{map[string]interface{}{
+			FieldProviderAWSAccessKey: "access-key",
+			FieldProviderAWSSecretKey: "secret-key",
+		}},
+	})
+	r.NotNil(toImageResolver(data))
+}
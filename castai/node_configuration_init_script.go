@@ -0,0 +1,290 @@
+package castai
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+)
+
+const (
+	FieldInitScriptPartContent     = "content"
+	FieldInitScriptPartContentType = "content_type"
+	FieldInitScriptPartFilename    = "filename"
+
+	InitScriptVarClusterID         = "${cluster_id}"
+	InitScriptVarConfigurationName = "${configuration_name}"
+	InitScriptVarRegion            = "${region}"
+
+	// maxInitScriptSize is the size limit of user data accepted by AWS, which is the strictest one of supported clouds.
+	maxInitScriptSize = 16 * 1024
+
+	initScriptMultipartBoundary = "==CASTAI-INIT-SCRIPT-BOUNDARY=="
+)
+
+var initScriptPartContentTypes = []string{
+	"text/x-shellscript",
+	"text/cloud-config",
+	"text/cloud-boothook",
+	"text/x-include-url",
+	"text/part-handler",
+}
+
+func initScriptPartSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		ConflictsWith: []string{FieldNodeConfigurationInitScript, FieldNodeConfigurationInitScriptText},
+		Description: "Init scripts to be composed into a single multipart MIME document run on your instance at launch. Should not contain any sensitive data. " +
+			"Content supports `${cluster_id}`, `${configuration_name}` and `${region}` placeholders",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				FieldInitScriptPartContent: {
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+					Description:      "Plain text content of the part",
+				},
+				FieldInitScriptPartContentType: {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          "text/x-shellscript",
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(initScriptPartContentTypes, false)),
+					Description:      "MIME content type of the part. One of: " + strings.Join(initScriptPartContentTypes, ", ") + ". Defaults to text/x-shellscript",
+				},
+				FieldInitScriptPartFilename: {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Optional file name of the part",
+				},
+			},
+		},
+	}
+}
+
+type initScriptPart struct {
+	content     string
+	contentType string
+	filename    string
+}
+
+// renderInitScript replaces supported placeholders in the init script. Placeholders without value are left untouched.
+func renderInitScript(script string, vars map[string]string) string {
+	oldnew := make([]string, 0, len(vars)*2)
+	for k, v := range vars {
+		oldnew = append(oldnew, k, v)
+	}
+	return strings.NewReplacer(oldnew...).Replace(script)
+}
+
+// composeMultipartInitScript composes init script parts into a multipart MIME document understood by cloud-init.
+func composeMultipartInitScript(parts []initScriptPart) (string, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := w.SetBoundary(initScriptMultipartBoundary); err != nil {
+		return "", err
+	}
+
+	for _, p := range parts {
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Type", fmt.Sprintf("%s; charset=\"us-ascii\"", p.contentType))
+		h.Set("MIME-Version", "1.0")
+		h.Set("Content-Transfer-Encoding", "7bit")
+		if p.filename != "" {
+			h.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", p.filename))
+		}
+		pw, err := w.CreatePart(h)
+		if err != nil {
+			return "", err
+		}
+		if _, err := pw.Write([]byte(p.content)); err != nil {
+			return "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	header := fmt.Sprintf("MIME-Version: 1.0\r\nContent-Type: multipart/mixed; boundary=%q\r\n\r\n", w.Boundary())
+	return header + body.String(), nil
+}
+
+func toInitScriptParts(items []interface{}) []initScriptPart {
+	parts := make([]initScriptPart, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		p := initScriptPart{
+			content:     obj[FieldInitScriptPartContent].(string),
+			contentType: obj[FieldInitScriptPartContentType].(string),
+		}
+		if v, ok := obj[FieldInitScriptPartFilename].(string); ok {
+			p.filename = v
+		}
+		if p.contentType == "" {
+			p.contentType = initScriptPartContentTypes[0]
+		}
+		parts = append(parts, p)
+	}
+	return parts
+}
+
+// composeInitScript builds plain text init script from either init_script_text or init_script_part attributes.
+func composeInitScript(text string, parts []interface{}, vars map[string]string) (string, error) {
+	if text != "" {
+		return renderInitScript(text, vars), nil
+	}
+	if len(parts) == 0 {
+		return "", nil
+	}
+
+	rendered := toInitScriptParts(parts)
+	for i := range rendered {
+		rendered[i].content = renderInitScript(rendered[i].content, vars)
+	}
+	return composeMultipartInitScript(rendered)
+}
+
+// composeNodeInitScript composes init script, rendering it into EKS user data when bottlerocket or al2023 settings are used.
+func composeNodeInitScript(eks interface{}, text string, parts []interface{}, vars map[string]string) (string, error) {
+	if bottlerocket, al2023 := eksUserDataSettings(eks); bottlerocket != nil || al2023 != nil {
+		return composeEKSUserData(bottlerocket, al2023, text, parts, vars)
+	}
+	return composeInitScript(text, parts, vars)
+}
+
+// usesInitScriptTemplate returns whether the init script is provided as plain text instead of base64 encoded one.
+func usesInitScriptTemplate(d *schema.ResourceData) bool {
+	if _, ok := d.GetOk(FieldNodeConfigurationInitScriptText); ok {
+		return true
+	}
+	if _, ok := d.GetOk(FieldNodeConfigurationInitScriptPart); ok {
+		return true
+	}
+	bottlerocket, al2023 := eksUserDataSettings(d.Get(FieldNodeConfigurationEKS))
+	return bottlerocket != nil || al2023 != nil
+}
+
+// initScriptFromResourceData returns base64 encoded init script, rendering plain text one if needed.
+func initScriptFromResourceData(d *schema.ResourceData, getCluster func() (*sdk.ExternalclusterV1Cluster, error)) (string, error) {
+	if !usesInitScriptTemplate(d) {
+		return d.Get(FieldNodeConfigurationInitScript).(string), nil
+	}
+
+	text := d.Get(FieldNodeConfigurationInitScriptText).(string)
+	parts := d.Get(FieldNodeConfigurationInitScriptPart).([]interface{})
+
+	vars := map[string]string{
+		InitScriptVarClusterID:         d.Get(FieldClusterID).(string),
+		InitScriptVarConfigurationName: d.Get(FieldNodeConfigurationName).(string),
+	}
+	if initScriptUsesVar(text, parts, InitScriptVarRegion) {
+		cluster, err := getCluster()
+		if err != nil {
+			return "", err
+		}
+		region, err := clusterRegion(cluster)
+		if err != nil {
+			return "", err
+		}
+		vars[InitScriptVarRegion] = region
+	}
+
+	script, err := composeNodeInitScript(d.Get(FieldNodeConfigurationEKS), text, parts, vars)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString([]byte(script)), nil
+}
+
+func initScriptUsesVar(text string, parts []interface{}, v string) bool {
+	if strings.Contains(text, v) {
+		return true
+	}
+	return lo.ContainsBy(toInitScriptParts(parts), func(p initScriptPart) bool {
+		return strings.Contains(p.content, v)
+	})
+}
+
+func clusterRegion(cluster *sdk.ExternalclusterV1Cluster) (string, error) {
+	switch {
+	case cluster.Eks != nil:
+		return lo.FromPtr(cluster.Eks.Region), nil
+	case cluster.Gke != nil:
+		return lo.FromPtr(cluster.Gke.Region), nil
+	case cluster.Aks != nil:
+		return lo.FromPtr(cluster.Aks.Region), nil
+	}
+	return "", fmt.Errorf("failed to resolve region of cluster %q", lo.FromPtr(cluster.Id))
+}
+
+// nodeConfigurationInitScriptDiff checks size of init script at plan time instead of failing the API call.
+func nodeConfigurationInitScriptDiff(diff *schema.ResourceDiff) error {
+	if !diff.NewValueKnown(FieldNodeConfigurationInitScript) ||
+		!diff.NewValueKnown(FieldNodeConfigurationInitScriptText) ||
+		!diff.NewValueKnown(FieldNodeConfigurationInitScriptPart) ||
+		!diff.NewValueKnown(FieldNodeConfigurationEKS) {
+		return nil
+	}
+
+	var script string
+	if v, ok := diff.GetOk(FieldNodeConfigurationInitScript); ok {
+		b, err := base64.StdEncoding.DecodeString(v.(string))
+		if err != nil {
+			return fmt.Errorf("decoding init script: %w", err)
+		}
+		script = string(b)
+	} else {
+		// Region is resolved on apply, so placeholder is used for estimating the size.
+		vars := map[string]string{}
+		if diff.NewValueKnown(FieldClusterID) {
+			vars[InitScriptVarClusterID] = diff.Get(FieldClusterID).(string)
+		}
+		if diff.NewValueKnown(FieldNodeConfigurationName) {
+			vars[InitScriptVarConfigurationName] = diff.Get(FieldNodeConfigurationName).(string)
+		}
+
+		var err error
+		script, err = composeNodeInitScript(
+			diff.Get(FieldNodeConfigurationEKS),
+			diff.Get(FieldNodeConfigurationInitScriptText).(string),
+			diff.Get(FieldNodeConfigurationInitScriptPart).([]interface{}),
+			vars,
+		)
+		if err != nil {
+			return fmt.Errorf("composing init script: %w", err)
+		}
+	}
+
+	if len(script) > maxInitScriptSize {
+		return fmt.Errorf("init script is %d bytes long, which exceeds the limit of %d bytes", len(script), maxInitScriptSize)
+	}
+	return nil
+}
+
+// setDriftedInitScript records init script changed outside of Terraform, so the configured one is applied again.
+// Drifted bottlerocket and al2023 settings are dropped when reading the eks block.
+func setDriftedInitScript(d *schema.ResourceData, actual string) error {
+	if _, ok := d.GetOk(FieldNodeConfigurationInitScriptPart); ok {
+		return d.Set(FieldNodeConfigurationInitScriptPart, nil)
+	}
+	if _, ok := d.GetOk(FieldNodeConfigurationInitScriptText); !ok {
+		return nil
+	}
+
+	b, err := base64.StdEncoding.DecodeString(actual)
+	if err != nil {
+		return fmt.Errorf("decoding init script: %w", err)
+	}
+	return d.Set(FieldNodeConfigurationInitScriptText, string(b))
+}
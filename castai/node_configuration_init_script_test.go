@@ -0,0 +1,240 @@
+package castai
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+func TestComposeInitScript(t *testing.T) {
+	vars := map[string]string{
+		InitScriptVarClusterID:         "b6bfc074-a267-400f-b8f1-db0850c369b1",
+		InitScriptVarConfigurationName: "default",
+	}
+
+	t.Run("plain text", func(t *testing.T) {
+		r := require.New(t)
+
+		script, err := composeInitScript("#!/bin/bash\necho ${cluster_id} ${configuration_name} ${region}\n", nil, vars)
+		r.NoError(err)
+		r.Equal("#!/bin/bash\necho b6bfc074-a267-400f-b8f1-db0850c369b1 default ${region}\n", script)
+	})
+
+	t.Run("multipart", func(t *testing.T) {
+		r := require.New(t)
+
+		script, err := composeInitScript("", []interface{}{
+			map[string]interface{}{
+				FieldInitScriptPartContent:     "#!/bin/bash\necho ${configuration_name}\n",
+				FieldInitScriptPartContentType: "text/x-shellscript",
+				FieldInitScriptPartFilename:    "",
+			},
+			map[string]interface{}{
+				FieldInitScriptPartContent:     "#cloud-config\npackages: [jq]\n",
+				FieldInitScriptPartContentType: "text/cloud-config",
+				FieldInitScriptPartFilename:    "packages.cfg",
+			},
+		}, vars)
+		r.NoError(err)
+
+		expected := "MIME-Version: 1.0\r\n" +
+			"Content-Type: multipart/mixed; boundary=\"==CASTAI-INIT-SCRIPT-BOUNDARY==\"\r\n" +
+			"\r\n" +
+			"--==CASTAI-INIT-SCRIPT-BOUNDARY==\r\n" +
+			"Content-Transfer-Encoding: 7bit\r\n" +
+			"Content-Type: text/x-shellscript; charset=\"us-ascii\"\r\n" +
+			"Mime-Version: 1.0\r\n" +
+			"\r\n" +
+			"#!/bin/bash\necho default\n" +
+			"\r\n" +
+			"--==CASTAI-INIT-SCRIPT-BOUNDARY==\r\n" +
+			"Content-Disposition: attachment; filename=\"packages.cfg\"\r\n" +
+			"Content-Transfer-Encoding: 7bit\r\n" +
+			"Content-Type: text/cloud-config; charset=\"us-ascii\"\r\n" +
+			"Mime-Version: 1.0\r\n" +
+			"\r\n" +
+			"#cloud-config\npackages: [jq]\n" +
+			"\r\n" +
+			"--==CASTAI-INIT-SCRIPT-BOUNDARY==--\r\n"
+		r.Equal(expected, script)
+	})
+}
+
+func TestNodeConfigurationResourceCreate_initScriptText(t *testing.T) {
+	r := require.New(t)
+	mockctrl := gomock.NewController(t)
+	mockClient := mock_sdk.NewMockClientInterface(mockctrl)
+
+	ctx := context.Background()
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterID := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	configID := "c0e6d9ac-dbe6-4a8f-8a2e-92e7a7b9a1f5"
+	expectedScript := base64.StdEncoding.EncodeToString([]byte("#!/bin/bash\necho default eu-central-1\n"))
+	body := func() io.ReadCloser {
+		return io.NopCloser(bytes.NewReader([]byte(`{
+		  "id": "` + configID + `",
+		  "name": "default",
+		  "diskCpuRatio": 0,
+		  "minDiskSize": 100,
+		  "subnets": ["subnet-1"],
+		  "tags": {},
+		  "initScript": "` + expectedScript + `"
+		}`)))
+	}
+	clusterBody := func() io.ReadCloser {
+		return io.NopCloser(bytes.NewReader([]byte(`{"id": "` + clusterID + `", "eks": {"region": "eu-central-1"}}`)))
+	}
+
+	mockClient.EXPECT().
+		ExternalClusterAPIGetCluster(gomock.Any(), clusterID).
+		DoAndReturn(func(_ context.Context, _ string) (*http.Response, error) {
+			return &http.Response{StatusCode: 200, Body: clusterBody(), Header: map[string][]string{"Content-Type": {"json"}}}, nil
+		}).Times(2)
+	mockClient.EXPECT().
+		NodeConfigurationAPICreateConfiguration(gomock.Any(), clusterID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, req sdk.NodeConfigurationAPICreateConfigurationJSONRequestBody) (*http.Response, error) {
+			r.Equal(expectedScript, *req.InitScript)
+			return &http.Response{StatusCode: 200, Body: body(), Header: map[string][]string{"Content-Type": {"json"}}}, nil
+		})
+	mockClient.EXPECT().
+		NodeConfigurationAPIGetConfiguration(gomock.Any(), clusterID, configID).
+		Return(&http.Response{StatusCode: 200, Body: body(), Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+
+	resource := resourceNodeConfiguration()
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterID:                       cty.StringVal(clusterID),
+		FieldNodeConfigurationName:           cty.StringVal("default"),
+		FieldNodeConfigurationSubnets:        cty.ListVal([]cty.Value{cty.StringVal("subnet-1")}),
+		FieldNodeConfigurationInitScriptText: cty.StringVal("#!/bin/bash\necho ${configuration_name} ${region}\n"),
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+
+	data := resource.Data(state)
+	result := resource.CreateContext(ctx, data, provider)
+	r.Nil(result)
+	r.Equal(configID, data.Id())
+	r.Equal("#!/bin/bash\necho ${configuration_name} ${region}\n", data.Get(FieldNodeConfigurationInitScriptText))
+	r.Empty(data.Get(FieldNodeConfigurationInitScript))
+}
+
+func TestNodeConfigurationResourceRead_initScriptTextDrift(t *testing.T) {
+	r := require.New(t)
+	mockctrl := gomock.NewController(t)
+	mockClient := mock_sdk.NewMockClientInterface(mockctrl)
+
+	ctx := context.Background()
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterID := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	configID := "c0e6d9ac-dbe6-4a8f-8a2e-92e7a7b9a1f5"
+	actualScript := base64.StdEncoding.EncodeToString([]byte("#!/bin/bash\necho changed\n"))
+	mockClient.EXPECT().
+		NodeConfigurationAPIGetConfiguration(gomock.Any(), clusterID, configID).
+		Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{
+		  "id": "` + configID + `",
+		  "name": "default",
+		  "subnets": ["subnet-1"],
+		  "tags": {},
+		  "initScript": "` + actualScript + `"
+		}`))), Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+
+	resource := resourceNodeConfiguration()
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterID:                       cty.StringVal(clusterID),
+		FieldNodeConfigurationName:           cty.StringVal("default"),
+		FieldNodeConfigurationInitScriptText: cty.StringVal("#!/bin/bash\necho ${configuration_name}\n"),
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+	state.ID = configID
+
+	data := resource.Data(state)
+	result := resource.ReadContext(ctx, data, provider)
+	r.Nil(result)
+	r.Equal("#!/bin/bash\necho changed\n", data.Get(FieldNodeConfigurationInitScriptText))
+}
+
+func TestNodeConfigurationResourceCustomizeDiff_initScriptSize(t *testing.T) {
+	tt := map[string]struct {
+		attributes map[string]cty.Value
+		errMsg     string
+	}{
+		"small plain text script": {
+			attributes: map[string]cty.Value{
+				FieldNodeConfigurationInitScriptText: cty.StringVal("#!/bin/bash\necho hello\n"),
+			},
+		},
+		"too large plain text script": {
+			attributes: map[string]cty.Value{
+				FieldNodeConfigurationInitScriptText: cty.StringVal("#!/bin/bash\n" + strings.Repeat("echo hello\n", 1500)),
+			},
+			errMsg: "init script is 16512 bytes long, which exceeds the limit of 16384 bytes",
+		},
+		"too large base64 encoded script": {
+			attributes: map[string]cty.Value{
+				FieldNodeConfigurationInitScript: cty.StringVal(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("#", 20000)))),
+			},
+			errMsg: "init script is 20000 bytes long",
+		},
+		"too large multipart script": {
+			attributes: map[string]cty.Value{
+				FieldNodeConfigurationInitScriptPart: cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						FieldInitScriptPartContent: cty.StringVal(strings.Repeat("#", 10000)),
+					}),
+					cty.ObjectVal(map[string]cty.Value{
+						FieldInitScriptPartContent: cty.StringVal(strings.Repeat("#", 10000)),
+					}),
+				}),
+			},
+			errMsg: "exceeds the limit of 16384 bytes",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			resource := resourceNodeConfiguration()
+
+			attributes := map[string]cty.Value{
+				FieldClusterID:                cty.StringVal("b6bfc074-a267-400f-b8f1-db0850c369b1"),
+				FieldNodeConfigurationName:    cty.StringVal("default"),
+				FieldNodeConfigurationSubnets: cty.ListVal([]cty.Value{cty.StringVal("subnet-1")}),
+			}
+			for k, v := range tc.attributes {
+				attributes[k] = v
+			}
+			val := cty.ObjectVal(attributes)
+			state := &terraform.InstanceState{RawConfig: val}
+			config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+			_, err := resource.Diff(context.Background(), state, config, nil)
+			if tc.errMsg == "" {
+				r.NoError(err)
+				return
+			}
+			r.Error(err)
+			r.Contains(err.Error(), tc.errMsg)
+		})
+	}
+}
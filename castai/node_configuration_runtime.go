@@ -0,0 +1,579 @@
+package castai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+)
+
+const (
+	FieldKubeletMaxPods                     = "max_pods"
+	FieldKubeletEvictionHard                = "eviction_hard"
+	FieldKubeletEvictionSoft                = "eviction_soft"
+	FieldKubeletEvictionSoftGracePeriod     = "eviction_soft_grace_period"
+	FieldKubeletKubeReserved                = "kube_reserved"
+	FieldKubeletSystemReserved              = "system_reserved"
+	FieldKubeletImageGCHighThresholdPercent = "image_gc_high_threshold_percent"
+	FieldKubeletImageGCLowThresholdPercent  = "image_gc_low_threshold_percent"
+	FieldKubeletCPUManagerPolicy            = "cpu_manager_policy"
+
+	FieldDockerLogDriver       = "log_driver"
+	FieldDockerLogOpts         = "log_opts"
+	FieldDockerRegistryMirrors = "registry_mirrors"
+)
+
+// kubeletConfig is the subset of upstream KubeletConfiguration (kubelet.config.k8s.io/v1beta1) exposed as typed attributes.
+type kubeletConfig struct {
+	MaxPods                     *int32            `json:"maxPods,omitempty"`
+	EvictionHard                map[string]string `json:"evictionHard,omitempty"`
+	EvictionSoft                map[string]string `json:"evictionSoft,omitempty"`
+	EvictionSoftGracePeriod     map[string]string `json:"evictionSoftGracePeriod,omitempty"`
+	KubeReserved                map[string]string `json:"kubeReserved,omitempty"`
+	SystemReserved              map[string]string `json:"systemReserved,omitempty"`
+	ImageGCHighThresholdPercent *int32            `json:"imageGCHighThresholdPercent,omitempty"`
+	ImageGCLowThresholdPercent  *int32            `json:"imageGCLowThresholdPercent,omitempty"`
+	CPUManagerPolicy            *string           `json:"cpuManagerPolicy,omitempty"`
+}
+
+// dockerConfig is the subset of upstream dockerd daemon.json configuration exposed as typed attributes.
+type dockerConfig struct {
+	LogDriver       *string           `json:"log-driver,omitempty"`
+	LogOpts         map[string]string `json:"log-opts,omitempty"`
+	RegistryMirrors []string          `json:"registry-mirrors,omitempty"`
+}
+
+var (
+	kubeletEvictionSignals = []string{
+		"memory.available",
+		"nodefs.available",
+		"nodefs.inodesFree",
+		"imagefs.available",
+		"imagefs.inodesFree",
+		"pid.available",
+	}
+	kubeletReservedResources  = []string{"cpu", "memory", "ephemeral-storage", "pid"}
+	kubeletCPUManagerPolicies = []string{"none", "static"}
+	dockerLogDrivers          = []string{
+		"none", "local", "json-file", "syslog", "journald", "gelf", "fluentd", "awslogs", "splunk", "etwlogs", "gcplogs", "logentries",
+	}
+
+	kubeletQuantityRegexp   = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)(([KMGTPE]i)|[numkMGTPE]|([eE][+-]?[0-9]+))?$`)
+	kubeletPercentageRegexp = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?%$`)
+
+	// kubeletConfigKeys lists upstream KubeletConfiguration fields, used to spot misspelled keys in kubelet_config.
+	kubeletConfigKeys = []string{
+		"address", "allowedUnsafeSysctls", "apiVersion", "authentication", "authorization", "cgroupDriver", "cgroupRoot",
+		"cgroupsPerQOS", "clusterDNS", "clusterDomain", "configMapAndSecretChangeDetectionStrategy", "containerLogMaxFiles",
+		"containerLogMaxSize", "containerLogMaxWorkers", "containerLogMonitorInterval", "containerRuntimeEndpoint",
+		"contentType", "cpuCFSQuota", "cpuCFSQuotaPeriod", "cpuManagerPolicy", "cpuManagerPolicyOptions",
+		"cpuManagerReconcilePeriod", "enableControllerAttachDetach", "enableContentionProfiling", "enableDebugFlagsHandler",
+		"enableDebuggingHandlers", "enableProfilingHandler", "enableServer", "enableSystemLogHandler", "enableSystemLogQuery",
+		"enforceNodeAllocatable", "eventBurst", "eventRecordQPS", "evictionHard", "evictionMaxPodGracePeriod",
+		"evictionMinimumReclaim", "evictionPressureTransitionPeriod", "evictionSoft", "evictionSoftGracePeriod",
+		"failSwapOn", "featureGates", "fileCheckFrequency", "hairpinMode", "healthzBindAddress", "healthzPort",
+		"httpCheckFrequency", "imageGCHighThresholdPercent", "imageGCLowThresholdPercent", "imageMaximumGCAge",
+		"imageMinimumGCAge", "imageServiceEndpoint", "iptablesDropBit", "iptablesMasqueradeBit", "kernelMemcgNotification",
+		"kind", "kubeAPIBurst", "kubeAPIQPS", "kubeReserved", "kubeReservedCgroup", "kubeletCgroups",
+		"localStorageCapacityIsolation", "logging", "makeIPTablesUtilChains", "maxOpenFiles", "maxParallelImagePulls",
+		"maxPods", "memoryManagerPolicy", "memorySwap", "memoryThrottlingFactor", "nodeLeaseDurationSeconds",
+		"nodeStatusMaxImages", "nodeStatusReportFrequency", "nodeStatusUpdateFrequency", "oomScoreAdj", "podCIDR",
+		"podPidsLimit", "podsPerCore", "port", "protectKernelDefaults", "providerID", "qosReserved", "readOnlyPort",
+		"registerNode", "registerWithTaints", "registryBurst", "registryPullQPS", "reservedMemory", "reservedSystemCPUs",
+		"resolvConf", "rotateCertificates", "runOnce", "runtimeRequestTimeout", "seccompDefault", "serializeImagePulls",
+		"serverTLSBootstrap", "showHiddenMetricsForVersion", "shutdownGracePeriod", "shutdownGracePeriodByPodPriority",
+		"shutdownGracePeriodCriticalPods", "staticPodPath", "staticPodURL", "staticPodURLHeader",
+		"streamingConnectionIdleTimeout", "syncFrequency", "systemCgroups", "systemReserved", "systemReservedCgroup",
+		"tlsCertFile", "tlsCipherSuites", "tlsMinVersion", "tlsPrivateKeyFile", "topologyManagerPolicy",
+		"topologyManagerPolicyOptions", "topologyManagerScope", "tracing", "volumePluginDir", "volumeStatsAggPeriod",
+	}
+
+	// dockerConfigKeys lists upstream dockerd daemon.json options, used to spot misspelled keys in docker_config.
+	dockerConfigKeys = []string{
+		"allow-nondistributable-artifacts", "api-cors-header", "authorization-plugins", "bip", "bridge", "cgroup-parent",
+		"containerd", "containerd-namespace", "containerd-plugins-namespace", "data-root", "debug",
+		"default-address-pools", "default-cgroupns-mode", "default-gateway", "default-gateway-v6", "default-ipc-mode",
+		"default-network-opts", "default-runtime", "default-shm-size", "default-ulimits", "dns", "dns-opts",
+		"dns-search", "exec-opts", "exec-root", "experimental", "features", "fixed-cidr", "fixed-cidr-v6", "group",
+		"host-gateway-ip", "hosts", "icc", "init", "init-path", "insecure-registries", "ip", "ip-forward", "ip-masq",
+		"ip6tables", "iptables", "ipv6", "labels", "live-restore", "log-driver", "log-format", "log-level", "log-opts",
+		"max-concurrent-downloads", "max-concurrent-uploads", "max-download-attempts", "mtu", "no-new-privileges",
+		"node-generic-resources", "oom-score-adjust", "pidfile", "raw-logs", "registry-mirrors", "runtimes",
+		"seccomp-profile", "selinux-enabled", "shutdown-timeout", "storage-driver", "storage-opts",
+		"swarm-default-advertise-addr", "tls", "tlscacert", "tlscert", "tlskey", "tlsverify", "userland-proxy",
+		"userland-proxy-path", "userns-remap",
+	}
+)
+
+func kubeletConfigSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{FieldNodeConfigurationKubeletConfig},
+		Description: "Typed alternative to `kubelet_config` covering the most common kubelet configuration properties. Applicable for EKS only. " +
+			"[Available values](https://kubernetes.io/docs/reference/config-api/kubelet-config.v1beta1/)",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				FieldKubeletMaxPods: {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+					Description:      "Maximum number of pods that can run on the node",
+				},
+				FieldKubeletEvictionHard: {
+					Type:             schema.TypeMap,
+					Optional:         true,
+					Elem:             &schema.Schema{Type: schema.TypeString},
+					ValidateDiagFunc: validateKubeletResourceMap(kubeletEvictionSignals, validateKubeletThreshold),
+					Description:      "Map of eviction signals to thresholds which trigger pod eviction, e.g. `memory.available = \"100Mi\"` or `nodefs.available = \"10%\"`",
+				},
+				FieldKubeletEvictionSoft: {
+					Type:             schema.TypeMap,
+					Optional:         true,
+					Elem:             &schema.Schema{Type: schema.TypeString},
+					ValidateDiagFunc: validateKubeletResourceMap(kubeletEvictionSignals, validateKubeletThreshold),
+					Description:      "Map of eviction signals to thresholds which trigger pod eviction after the grace period. Every signal requires a matching `eviction_soft_grace_period`",
+				},
+				FieldKubeletEvictionSoftGracePeriod: {
+					Type:             schema.TypeMap,
+					Optional:         true,
+					Elem:             &schema.Schema{Type: schema.TypeString},
+					ValidateDiagFunc: validateKubeletResourceMap(kubeletEvictionSignals, validateKubeletDuration),
+					Description:      "Map of eviction signals to durations a soft eviction threshold must hold before triggering pod eviction, e.g. `memory.available = \"1m30s\"`",
+				},
+				FieldKubeletKubeReserved: {
+					Type:             schema.TypeMap,
+					Optional:         true,
+					Elem:             &schema.Schema{Type: schema.TypeString},
+					ValidateDiagFunc: validateKubeletResourceMap(kubeletReservedResources, validateKubeletQuantity),
+					Description:      "Resources reserved for Kubernetes system components. Supported keys: `cpu`, `memory`, `ephemeral-storage`, `pid`",
+				},
+				FieldKubeletSystemReserved: {
+					Type:             schema.TypeMap,
+					Optional:         true,
+					Elem:             &schema.Schema{Type: schema.TypeString},
+					ValidateDiagFunc: validateKubeletResourceMap(kubeletReservedResources, validateKubeletQuantity),
+					Description:      "Resources reserved for non-Kubernetes components. Supported keys: `cpu`, `memory`, `ephemeral-storage`, `pid`",
+				},
+				FieldKubeletImageGCHighThresholdPercent: {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 100)),
+					Description:      "Percent of disk usage after which image garbage collection is always run",
+				},
+				FieldKubeletImageGCLowThresholdPercent: {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 100)),
+					Description:      "Percent of disk usage before which image garbage collection is never run. Must be lower than `image_gc_high_threshold_percent`",
+				},
+				FieldKubeletCPUManagerPolicy: {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(kubeletCPUManagerPolicies, false)),
+					Description:      "CPU manager policy to use. One of: none, static",
+				},
+			},
+		},
+	}
+}
+
+func dockerConfigSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{FieldNodeConfigurationDockerConfig},
+		Description: "Typed alternative to `docker_config` covering the most common docker daemon configuration properties. Applicable for EKS only. " +
+			"[Available values](https://docs.docker.com/engine/reference/commandline/dockerd/#daemon-configuration-file)",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				FieldDockerLogDriver: {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(dockerLogDrivers, false)),
+					Description:      "Default logging driver for containers",
+				},
+				FieldDockerLogOpts: {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Logging driver options, e.g. `max-size = \"10m\"`",
+				},
+				FieldDockerRegistryMirrors: {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:             schema.TypeString,
+						ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithScheme([]string{"http", "https"})),
+					},
+					Description: "Registry mirrors to pull images from",
+				},
+			},
+		},
+	}
+}
+
+func validateKubeletResourceMap(keys []string, validateValue func(string) error) schema.SchemaValidateDiagFunc {
+	return func(i interface{}, path cty.Path) diag.Diagnostics {
+		var diags diag.Diagnostics
+		for k, v := range i.(map[string]interface{}) {
+			if !lo.Contains(keys, k) {
+				diags = append(diags, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       fmt.Sprintf("unsupported key %q", k),
+					Detail:        fmt.Sprintf("Expected one of: %v", keys),
+					AttributePath: append(path, cty.IndexStep{Key: cty.StringVal(k)}),
+				})
+				continue
+			}
+			if err := validateValue(v.(string)); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       fmt.Sprintf("invalid value for %q", k),
+					Detail:        err.Error(),
+					AttributePath: append(path, cty.IndexStep{Key: cty.StringVal(k)}),
+				})
+			}
+		}
+		return diags
+	}
+}
+
+func validateKubeletQuantity(v string) error {
+	if !kubeletQuantityRegexp.MatchString(v) {
+		return fmt.Errorf("%q is not a valid resource quantity", v)
+	}
+	return nil
+}
+
+func validateKubeletThreshold(v string) error {
+	if kubeletPercentageRegexp.MatchString(v) {
+		return nil
+	}
+	if err := validateKubeletQuantity(v); err != nil {
+		return fmt.Errorf("%q is neither a resource quantity nor a percentage", v)
+	}
+	return nil
+}
+
+func validateKubeletDuration(v string) error {
+	if _, err := time.ParseDuration(v); err != nil {
+		return fmt.Errorf("%q is not a valid duration: %w", v, err)
+	}
+	return nil
+}
+
+// validateKubeletConfigJSON checks kubelet_config against the typed properties and warns about unknown keys.
+func validateKubeletConfigJSON(i interface{}, path cty.Path) diag.Diagnostics {
+	return validateRuntimeConfigJSON(i.(string), path, &kubeletConfig{}, kubeletConfigKeys)
+}
+
+// validateDockerConfigJSON checks docker_config against the typed properties and warns about unknown keys.
+func validateDockerConfigJSON(i interface{}, path cty.Path) diag.Diagnostics {
+	return validateRuntimeConfigJSON(i.(string), path, &dockerConfig{}, dockerConfigKeys)
+}
+
+func validateRuntimeConfigJSON(v string, path cty.Path, typed any, knownKeys []string) diag.Diagnostics {
+	m, err := stringToMap(v)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "invalid JSON",
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+	if err := json.Unmarshal([]byte(v), typed); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "invalid configuration",
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+
+	var diags diag.Diagnostics
+	for _, k := range lo.Keys(m) {
+		if !lo.Contains(knownKeys, k) {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       fmt.Sprintf("unknown configuration property %q", k),
+				Detail:        "The property is not recognized and will most likely be ignored. Check it for typos.",
+				AttributePath: path,
+			})
+		}
+	}
+	sort.Slice(diags, func(i, j int) bool {
+		return diags[i].Summary < diags[j].Summary
+	})
+	return diags
+}
+
+// suppressEquivalentJSONDiffs suppresses diffs of JSON attributes which only differ in formatting or key order.
+func suppressEquivalentJSONDiffs(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	if oldValue == "" || newValue == "" {
+		return oldValue == newValue
+	}
+	o, err := normalizeJSON([]byte(oldValue))
+	if err != nil {
+		return false
+	}
+	n, err := normalizeJSON([]byte(newValue))
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(o, n)
+}
+
+// validateKubeletConfig checks kubelet properties which depend on each other.
+func validateKubeletConfig(cfg *kubeletConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.ImageGCHighThresholdPercent != nil && cfg.ImageGCLowThresholdPercent != nil &&
+		*cfg.ImageGCLowThresholdPercent >= *cfg.ImageGCHighThresholdPercent {
+		return fmt.Errorf("image garbage collection low threshold (%d%%) must be lower than the high threshold (%d%%)",
+			*cfg.ImageGCLowThresholdPercent, *cfg.ImageGCHighThresholdPercent)
+	}
+	for signal := range cfg.EvictionSoft {
+		if _, ok := cfg.EvictionSoftGracePeriod[signal]; !ok {
+			return fmt.Errorf("soft eviction threshold %q requires a grace period", signal)
+		}
+	}
+	for signal := range cfg.EvictionSoftGracePeriod {
+		if _, ok := cfg.EvictionSoft[signal]; !ok {
+			return fmt.Errorf("soft eviction grace period %q requires a soft eviction threshold", signal)
+		}
+	}
+	return nil
+}
+
+// toKubeletConfig converts the typed block to kubelet configuration. Zero numbers are kept only when configured explicitly,
+// as reported by configured, since the block reads unset numbers as zeros.
+func toKubeletConfig(obj map[string]interface{}, configured func(field string) bool) *kubeletConfig {
+	if obj == nil {
+		return nil
+	}
+
+	out := &kubeletConfig{}
+	if v, ok := obj[FieldKubeletMaxPods].(int); ok && v != 0 {
+		out.MaxPods = toPtr(int32(v))
+	}
+	if v, ok := obj[FieldKubeletEvictionHard].(map[string]interface{}); ok && len(v) > 0 {
+		out.EvictionHard = toStringMap(v)
+	}
+	if v, ok := obj[FieldKubeletEvictionSoft].(map[string]interface{}); ok && len(v) > 0 {
+		out.EvictionSoft = toStringMap(v)
+	}
+	if v, ok := obj[FieldKubeletEvictionSoftGracePeriod].(map[string]interface{}); ok && len(v) > 0 {
+		out.EvictionSoftGracePeriod = toStringMap(v)
+	}
+	if v, ok := obj[FieldKubeletKubeReserved].(map[string]interface{}); ok && len(v) > 0 {
+		out.KubeReserved = toStringMap(v)
+	}
+	if v, ok := obj[FieldKubeletSystemReserved].(map[string]interface{}); ok && len(v) > 0 {
+		out.SystemReserved = toStringMap(v)
+	}
+	if v, ok := obj[FieldKubeletImageGCHighThresholdPercent].(int); ok && (v != 0 || configured(FieldKubeletImageGCHighThresholdPercent)) {
+		out.ImageGCHighThresholdPercent = toPtr(int32(v))
+	}
+	if v, ok := obj[FieldKubeletImageGCLowThresholdPercent].(int); ok && (v != 0 || configured(FieldKubeletImageGCLowThresholdPercent)) {
+		out.ImageGCLowThresholdPercent = toPtr(int32(v))
+	}
+	if v, ok := obj[FieldKubeletCPUManagerPolicy].(string); ok && v != "" {
+		out.CPUManagerPolicy = toPtr(v)
+	}
+
+	return out
+}
+
+func flattenKubeletConfig(config *kubeletConfig) []map[string]interface{} {
+	if config == nil {
+		return nil
+	}
+
+	m := map[string]interface{}{}
+	if v := config.MaxPods; v != nil {
+		m[FieldKubeletMaxPods] = *v
+	}
+	if v := config.EvictionHard; v != nil {
+		m[FieldKubeletEvictionHard] = v
+	}
+	if v := config.EvictionSoft; v != nil {
+		m[FieldKubeletEvictionSoft] = v
+	}
+	if v := config.EvictionSoftGracePeriod; v != nil {
+		m[FieldKubeletEvictionSoftGracePeriod] = v
+	}
+	if v := config.KubeReserved; v != nil {
+		m[FieldKubeletKubeReserved] = v
+	}
+	if v := config.SystemReserved; v != nil {
+		m[FieldKubeletSystemReserved] = v
+	}
+	if v := config.ImageGCHighThresholdPercent; v != nil {
+		m[FieldKubeletImageGCHighThresholdPercent] = *v
+	}
+	if v := config.ImageGCLowThresholdPercent; v != nil {
+		m[FieldKubeletImageGCLowThresholdPercent] = *v
+	}
+	if v := config.CPUManagerPolicy; v != nil {
+		m[FieldKubeletCPUManagerPolicy] = *v
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func toDockerConfig(obj map[string]interface{}) *dockerConfig {
+	if obj == nil {
+		return nil
+	}
+
+	out := &dockerConfig{}
+	if v, ok := obj[FieldDockerLogDriver].(string); ok && v != "" {
+		out.LogDriver = toPtr(v)
+	}
+	if v, ok := obj[FieldDockerLogOpts].(map[string]interface{}); ok && len(v) > 0 {
+		out.LogOpts = toStringMap(v)
+	}
+	if v, ok := obj[FieldDockerRegistryMirrors].([]interface{}); ok && len(v) > 0 {
+		out.RegistryMirrors = toStringList(v)
+	}
+
+	return out
+}
+
+func flattenDockerConfig(config *dockerConfig) []map[string]interface{} {
+	if config == nil {
+		return nil
+	}
+
+	m := map[string]interface{}{}
+	if v := config.LogDriver; v != nil {
+		m[FieldDockerLogDriver] = *v
+	}
+	if v := config.LogOpts; v != nil {
+		m[FieldDockerLogOpts] = v
+	}
+	if v := config.RegistryMirrors; v != nil {
+		m[FieldDockerRegistryMirrors] = v
+	}
+
+	return []map[string]interface{}{m}
+}
+
+// configuredBlockFields reports whether fields of the single item block are set in the raw configuration,
+// telling explicit zero values from unset ones.
+func configuredBlockFields(config cty.Value, block string) func(field string) bool {
+	return func(field string) bool {
+		if config.IsNull() || !config.IsKnown() || !config.Type().HasAttribute(block) {
+			return false
+		}
+
+		items := config.GetAttr(block)
+		if items.IsNull() || !items.IsKnown() || items.LengthInt() == 0 {
+			return false
+		}
+
+		item := items.Index(cty.NumberIntVal(0))
+		if item.IsNull() || !item.Type().HasAttribute(field) {
+			return false
+		}
+		return !item.GetAttr(field).IsNull()
+	}
+}
+
+// untypedRuntimeConfig returns properties of kubelet or docker configuration returned by the API which have no typed attribute.
+func untypedRuntimeConfig(m map[string]interface{}, typed any) map[string]interface{} {
+	t := reflect.TypeOf(typed).Elem()
+	typedKeys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		typedKeys = append(typedKeys, strings.Split(t.Field(i).Tag.Get("json"), ",")[0])
+	}
+	return lo.OmitByKeys(m, typedKeys)
+}
+
+// setUntypedRuntimeConfig keeps properties without typed attributes in the JSON attribute. The attribute conflicts with
+// the typed block, so the next plan removes them.
+func setUntypedRuntimeConfig(d *schema.ResourceData, key string, untyped map[string]interface{}) error {
+	if len(untyped) == 0 {
+		return d.Set(key, "")
+	}
+	b, err := json.Marshal(untyped)
+	if err != nil {
+		return err
+	}
+	return d.Set(key, string(b))
+}
+
+// toRuntimeConfigMap converts typed kubelet or docker configuration to the free form map accepted by the API.
+func toRuntimeConfigMap(typed any) (map[string]interface{}, error) {
+	b, err := json.Marshal(typed)
+	if err != nil {
+		return nil, err
+	}
+	return stringToMap(string(b))
+}
+
+// fromRuntimeConfigMap converts free form kubelet or docker configuration returned by the API to its typed form.
+func fromRuntimeConfigMap(m map[string]interface{}, typed any) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, typed)
+}
+
+// kubeletConfigFromResourceData returns kubelet configuration from either the typed block or the JSON attribute.
+func kubeletConfigFromResourceData(d *schema.ResourceData) (map[string]interface{}, error) {
+	if v, ok := d.GetOk(FieldNodeConfigurationKubelet); ok && len(v.([]interface{})) > 0 {
+		obj, _ := v.([]interface{})[0].(map[string]interface{})
+		return toRuntimeConfigMap(toKubeletConfig(obj, configuredBlockFields(d.GetRawConfig(), FieldNodeConfigurationKubelet)))
+	}
+	if v, ok := d.GetOk(FieldNodeConfigurationKubeletConfig); ok {
+		return stringToMap(v.(string))
+	}
+	return nil, nil
+}
+
+// dockerConfigFromResourceData returns docker daemon configuration from either the typed block or the JSON attribute.
+func dockerConfigFromResourceData(d *schema.ResourceData) (map[string]interface{}, error) {
+	if v, ok := d.GetOk(FieldNodeConfigurationDocker); ok && len(v.([]interface{})) > 0 {
+		obj, _ := v.([]interface{})[0].(map[string]interface{})
+		return toRuntimeConfigMap(toDockerConfig(obj))
+	}
+	if v, ok := d.GetOk(FieldNodeConfigurationDockerConfig); ok {
+		return stringToMap(v.(string))
+	}
+	return nil, nil
+}
+
+func nodeConfigurationRuntimeDiff(diff *schema.ResourceDiff) error {
+	var cfg *kubeletConfig
+	if v, ok := diff.GetOk(FieldNodeConfigurationKubelet); ok && len(v.([]interface{})) > 0 {
+		obj, _ := v.([]interface{})[0].(map[string]interface{})
+		cfg = toKubeletConfig(obj, configuredBlockFields(diff.GetRawConfig(), FieldNodeConfigurationKubelet))
+	} else if v, ok := diff.GetOk(FieldNodeConfigurationKubeletConfig); ok && diff.NewValueKnown(FieldNodeConfigurationKubeletConfig) {
+		cfg = &kubeletConfig{}
+		if err := json.Unmarshal([]byte(v.(string)), cfg); err != nil {
+			return fmt.Errorf("parsing kubelet config: %w", err)
+		}
+	}
+
+	if err := validateKubeletConfig(cfg); err != nil {
+		return fmt.Errorf("invalid kubelet config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+package castai
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+func TestNodeConfigurationRuntimeConfigJSONValidation(t *testing.T) {
+	tt := map[string]struct {
+		validate func(interface{}, cty.Path) diag.Diagnostics
+		value    string
+		errors   int
+		warnings int
+	}{
+		"valid kubelet config": {
+			validate: validateKubeletConfigJSON,
+			value:    `{"maxPods": 110, "evictionHard": {"memory.available": "100Mi"}}`,
+		},
+		"misspelled kubelet property": {
+			validate: validateKubeletConfigJSON,
+			value:    `{"evictonHard": {"memory.available": "100Mi"}}`,
+			warnings: 1,
+		},
+		"kubelet property of wrong type": {
+			validate: validateKubeletConfigJSON,
+			value:    `{"maxPods": "110"}`,
+			errors:   1,
+		},
+		"invalid json": {
+			validate: validateKubeletConfigJSON,
+			value:    `{"maxPods": `,
+			errors:   1,
+		},
+		"valid docker config": {
+			validate: validateDockerConfigJSON,
+			value:    `{"log-driver": "json-file", "registry-mirrors": ["https://mirror.gcr.io"], "max-concurrent-downloads": 10}`,
+		},
+		"misspelled docker property": {
+			validate: validateDockerConfigJSON,
+			value:    `{"registry-mirror": ["https://mirror.gcr.io"]}`,
+			warnings: 1,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			diags := tc.validate(tc.value, cty.Path{})
+
+			var errors, warnings int
+			for _, d := range diags {
+				if d.Severity == diag.Error {
+					errors++
+				} else {
+					warnings++
+				}
+			}
+			r.Equal(tc.errors, errors)
+			r.Equal(tc.warnings, warnings)
+		})
+	}
+}
+
+func TestNodeConfigurationKubeletResourceMapValidation(t *testing.T) {
+	r := require.New(t)
+	validate := validateKubeletResourceMap(kubeletEvictionSignals, validateKubeletThreshold)
+
+	r.Empty(validate(map[string]interface{}{"memory.available": "100Mi", "nodefs.available": "10%"}, cty.Path{}))
+	r.Len(validate(map[string]interface{}{"memory.availabel": "100Mi"}, cty.Path{}), 1)
+	r.Len(validate(map[string]interface{}{"memory.available": "lots"}, cty.Path{}), 1)
+}
+
+func TestSuppressEquivalentJSONDiffs(t *testing.T) {
+	r := require.New(t)
+
+	r.True(suppressEquivalentJSONDiffs("", `{"maxPods":110,"registryBurst":20}`, `{ "registryBurst": 20,  "maxPods": 110 }`, nil))
+	r.False(suppressEquivalentJSONDiffs("", `{"maxPods":110}`, `{"maxPods":100}`, nil))
+	r.False(suppressEquivalentJSONDiffs("", `{"maxPods":110}`, "", nil))
+	r.True(suppressEquivalentJSONDiffs("", "", "", nil))
+}
+
+func TestNodeConfigurationResourceCreate_typedRuntimeConfig(t *testing.T) {
+	r := require.New(t)
+	mockctrl := gomock.NewController(t)
+	mockClient := mock_sdk.NewMockClientInterface(mockctrl)
+
+	ctx := context.Background()
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterID := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	configID := "c0e6d9ac-dbe6-4a8f-8a2e-92e7a7b9a1f5"
+	body := `{
+	  "id": "` + configID + `",
+	  "name": "default",
+	  "default": false,
+	  "diskCpuRatio": 0,
+	  "minDiskSize": 100,
+	  "subnets": ["subnet-1"],
+	  "tags": {},
+	  "dockerConfig": {"log-driver": "json-file", "log-opts": {"max-size": "10m"}},
+	  "kubeletConfig": {"maxPods": 58, "evictionHard": {"memory.available": "100Mi"}, "imageGCHighThresholdPercent": 85, "imageGCLowThresholdPercent": 0}
+	}`
+
+	mockClient.EXPECT().
+		ExternalClusterAPIGetCluster(gomock.Any(), clusterID).
+		Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"id": "` + clusterID + `", "eks": {"region": "eu-central-1"}}`))), Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+	mockClient.EXPECT().
+		NodeConfigurationAPICreateConfiguration(gomock.Any(), clusterID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, req sdk.NodeConfigurationAPICreateConfigurationJSONRequestBody) (*http.Response, error) {
+			r.Equal(map[string]interface{}{
+				"maxPods":                     float64(58),
+				"evictionHard":                map[string]interface{}{"memory.available": "100Mi"},
+				"imageGCHighThresholdPercent": float64(85),
+				"imageGCLowThresholdPercent":  float64(0),
+			}, *req.KubeletConfig)
+			r.Equal(map[string]interface{}{
+				"log-driver": "json-file",
+				"log-opts":   map[string]interface{}{"max-size": "10m"},
+			}, *req.DockerConfig)
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(body))), Header: map[string][]string{"Content-Type": {"json"}}}, nil
+		})
+	mockClient.EXPECT().
+		NodeConfigurationAPIGetConfiguration(gomock.Any(), clusterID, configID).
+		Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(body))), Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+
+	resource := resourceNodeConfiguration()
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterID:                cty.StringVal(clusterID),
+		FieldNodeConfigurationName:    cty.StringVal("default"),
+		FieldNodeConfigurationSubnets: cty.ListVal([]cty.Value{cty.StringVal("subnet-1")}),
+		FieldNodeConfigurationKubelet: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			FieldKubeletMaxPods: cty.NumberIntVal(58),
+			FieldKubeletEvictionHard: cty.MapVal(map[string]cty.Value{
+				"memory.available": cty.StringVal("100Mi"),
+			}),
+			FieldKubeletImageGCHighThresholdPercent: cty.NumberIntVal(85),
+			FieldKubeletImageGCLowThresholdPercent:  cty.NumberIntVal(0),
+		})}),
+		FieldNodeConfigurationDocker: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			FieldDockerLogDriver: cty.StringVal("json-file"),
+			FieldDockerLogOpts: cty.MapVal(map[string]cty.Value{
+				"max-size": cty.StringVal("10m"),
+			}),
+		})}),
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+	state.RawConfig = val
+
+	data := resource.Data(state)
+	result := resource.CreateContext(ctx, data, provider)
+	r.Nil(result)
+	r.Equal(configID, data.Id())
+	r.Equal(58, data.Get(FieldNodeConfigurationKubelet+".0."+FieldKubeletMaxPods))
+	r.Equal(0, data.Get(FieldNodeConfigurationKubelet+".0."+FieldKubeletImageGCLowThresholdPercent))
+	r.Equal(map[string]interface{}{"memory.available": "100Mi"}, data.Get(FieldNodeConfigurationKubelet+".0."+FieldKubeletEvictionHard))
+	r.Equal("json-file", data.Get(FieldNodeConfigurationDocker+".0."+FieldDockerLogDriver))
+	r.Empty(data.Get(FieldNodeConfigurationKubeletConfig))
+	r.Empty(data.Get(FieldNodeConfigurationDockerConfig))
+}
+
+func TestNodeConfigurationResourceRead_untypedRuntimeConfig(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterID := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	configID := "c0e6d9ac-dbe6-4a8f-8a2e-92e7a7b9a1f5"
+	mockClient.EXPECT().
+		NodeConfigurationAPIGetConfiguration(gomock.Any(), clusterID, configID).
+		Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{
+		  "id": "` + configID + `",
+		  "name": "default",
+		  "minDiskSize": 100,
+		  "subnets": ["subnet-1"],
+		  "tags": {},
+		  "dockerConfig": {"log-driver": "json-file"},
+		  "kubeletConfig": {"maxPods": 58, "cpuCFSQuota": false}
+		}`))), Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+
+	resource := resourceNodeConfiguration()
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterID:                cty.StringVal(clusterID),
+		FieldNodeConfigurationName:    cty.StringVal("default"),
+		FieldNodeConfigurationSubnets: cty.ListVal([]cty.Value{cty.StringVal("subnet-1")}),
+		FieldNodeConfigurationKubelet: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			FieldKubeletMaxPods: cty.NumberIntVal(58),
+		})}),
+		FieldNodeConfigurationDocker: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			FieldDockerLogDriver: cty.StringVal("json-file"),
+		})}),
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+	state.ID = configID
+
+	data := resource.Data(state)
+	result := resource.ReadContext(context.Background(), data, provider)
+	r.Nil(result)
+	r.Equal(58, data.Get(FieldNodeConfigurationKubelet+".0."+FieldKubeletMaxPods))
+	r.Equal(`{"cpuCFSQuota":false}`, data.Get(FieldNodeConfigurationKubeletConfig))
+	r.Empty(data.Get(FieldNodeConfigurationDockerConfig))
+}
+
+func TestNodeConfigurationResourceCustomizeDiff_kubelet(t *testing.T) {
+	tt := map[string]struct {
+		kubelet cty.Value
+		errMsg  string
+	}{
+		"valid thresholds": {
+			kubelet: cty.ObjectVal(map[string]cty.Value{
+				FieldKubeletImageGCHighThresholdPercent: cty.NumberIntVal(85),
+				FieldKubeletImageGCLowThresholdPercent:  cty.NumberIntVal(80),
+			}),
+		},
+		"low image gc threshold above high one": {
+			kubelet: cty.ObjectVal(map[string]cty.Value{
+				FieldKubeletImageGCHighThresholdPercent: cty.NumberIntVal(80),
+				FieldKubeletImageGCLowThresholdPercent:  cty.NumberIntVal(85),
+			}),
+			errMsg: "low threshold (85%) must be lower than the high threshold (80%)",
+		},
+		"soft eviction without grace period": {
+			kubelet: cty.ObjectVal(map[string]cty.Value{
+				FieldKubeletEvictionSoft: cty.MapVal(map[string]cty.Value{
+					"memory.available": cty.StringVal("200Mi"),
+				}),
+			}),
+			errMsg: `soft eviction threshold "memory.available" requires a grace period`,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			resource := resourceNodeConfiguration()
+			val := cty.ObjectVal(map[string]cty.Value{
+				FieldClusterID:                cty.StringVal("b6bfc074-a267-400f-b8f1-db0850c369b1"),
+				FieldNodeConfigurationName:    cty.StringVal("default"),
+				FieldNodeConfigurationSubnets: cty.ListVal([]cty.Value{cty.StringVal("subnet-1")}),
+				FieldNodeConfigurationKubelet: cty.ListVal([]cty.Value{tc.kubelet}),
+			})
+			state := &terraform.InstanceState{RawConfig: val}
+			config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+			provider := newClusterProvider(t, "b6bfc074-a267-400f-b8f1-db0850c369b1", `{"eks": {"region": "eu-central-1"}}`)
+			_, err := resource.Diff(context.Background(), state, config, provider)
+			if tc.errMsg == "" {
+				r.NoError(err)
+				return
+			}
+			r.Error(err)
+			r.Contains(err.Error(), tc.errMsg)
+		})
+	}
+}
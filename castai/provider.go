@@ -11,8 +11,16 @@ import (
 	"github.com/castai/terraform-provider-castai/castai/sdk"
 )
 
+const (
+	FieldProviderAWS             = "aws"
+	FieldProviderAWSAccessKey    = "access_key"
+	FieldProviderAWSSecretKey    = "secret_key"
+	FieldProviderAWSSessionToken = "session_token"
+)
+
 type ProviderConfig struct {
-	api *sdk.ClientWithResponses
+	api           *sdk.ClientWithResponses
+	imageResolver imageResolver
 }
 
 func Provider(version string) *schema.Provider {
@@ -31,6 +39,35 @@ func Provider(version string) *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("CASTAI_API_TOKEN", nil),
 				Description: "The token used to connect to CAST AI API.",
 			},
+			FieldProviderAWS: {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Description: "AWS credentials used to resolve `image_selector` of `castai_node_configuration` from public SSM parameters. " +
+					"Only these credentials are used, credentials from the environment or shared AWS configuration are ignored.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						FieldProviderAWSAccessKey: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "AWS access key ID.",
+						},
+						FieldProviderAWSSecretKey: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "AWS secret access key.",
+						},
+						FieldProviderAWSSessionToken: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "AWS session token of temporary credentials.",
+						},
+					},
+				},
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -41,20 +78,31 @@ func Provider(version string) *schema.Provider {
 			"castai_autoscaler":                 resourceAutoscaler(),
 			"castai_evictor_advanced_config":    resourceEvictionConfig(),
 			"castai_node_template":              resourceNodeTemplate(),
+			"castai_default_node_template":      resourceDefaultNodeTemplate(),
 			"castai_rebalancing_schedule":       resourceRebalancingSchedule(),
 			"castai_rebalancing_job":            resourceRebalancingJob(),
+			"castai_rebalancing_jobs":           resourceRebalancingJobs(),
 			"castai_node_configuration":         resourceNodeConfiguration(),
 			"castai_node_configuration_default": resourceNodeConfigurationDefault(),
 			"castai_eks_user_arn":               resourceEKSClusterUserARN(),
+			"castai_reservation":                resourceReservation(),
 			"castai_reservations":               resourceReservations(),
 			"castai_organization_members":       resourceOrganizationMembers(),
 			"castai_sso_connection":             resourceSSOConnection(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"castai_eks_settings":      dataSourceEKSSettings(),
-			"castai_gke_user_policies": dataSourceGKEPolicies(),
-			"castai_organization":      dataSourceOrganization(),
+			"castai_eks_settings":                  dataSourceEKSSettings(),
+			"castai_gke_user_policies":             dataSourceGKEPolicies(),
+			"castai_organization":                  dataSourceOrganization(),
+			"castai_node_templates":                dataSourceNodeTemplates(),
+			"castai_node_configuration_suggestion": dataSourceNodeConfigurationSuggestion(),
+			"castai_node_configurations":           dataSourceNodeConfigurations(),
+			"castai_rebalancing_jobs":              dataSourceRebalancingJobs(),
+			"castai_rebalancing_schedule_preview":  dataSourceRebalancingSchedulePreview(),
+			"castai_rebalancing_time_zones":        dataSourceRebalancingTimeZones(),
+			"castai_reservations_balance":          dataSourceReservationsBalance(),
+			"castai_resource_usage":                dataSourceResourceUsage(),
 
 			// TODO: remove in next major release
 			"castai_eks_user_arn": dataSourceEKSClusterUserARN(),
@@ -77,6 +125,20 @@ func providerConfigure(version string) schema.ConfigureContextFunc {
 			return nil, diag.FromErr(err)
 		}
 
-		return &ProviderConfig{api: client}, nil
+		return &ProviderConfig{api: client, imageResolver: toImageResolver(data)}, nil
+	}
+}
+
+// toImageResolver returns resolver of image selectors, or nil when AWS credentials aren't configured.
+func toImageResolver(data *schema.ResourceData) imageResolver {
+	v, ok := data.GetOk(FieldProviderAWS)
+	if !ok || len(v.([]interface{})) == 0 {
+		return nil
 	}
+	awsConfig, _ := v.([]interface{})[0].(map[string]interface{})
+	return newSSMImageResolver(
+		awsConfig[FieldProviderAWSAccessKey].(string),
+		awsConfig[FieldProviderAWSSecretKey].(string),
+		awsConfig[FieldProviderAWSSessionToken].(string),
+	)
 }
@@ -0,0 +1,182 @@
+package castai
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/samber/lo"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+)
+
+const (
+	FieldRebalancingScheduleAt              = "at"
+	FieldRebalancingScheduleBlackoutWindows = "blackout_windows"
+	FieldRebalancingScheduleSuspended       = "suspended"
+
+	week = 7 * 24 * time.Hour
+	// oneShotGracePeriod is how long a one-shot schedule isn't reported as suspended after the trigger time
+	// when the API does not report its jobs as triggered yet.
+	oneShotGracePeriod = time.Hour
+)
+
+// Rebalancing schedules API supports cron expressions only, so blackout windows and one-shot triggers aren't enforced
+// by the API. The schedule reports them in the suspended attribute, refreshed on every read, which rebalancing job
+// resources consume to disable the jobs on apply.
+
+type blackoutWindow struct {
+	start, end time.Time
+	weekly     bool
+}
+
+func toBlackoutWindows(raw []any) ([]blackoutWindow, error) {
+	windows := make([]blackoutWindow, 0, len(raw))
+	for i, w := range raw {
+		window, ok := w.(map[string]any)
+		if !ok {
+			continue
+		}
+		start, err := time.Parse(time.RFC3339, window["start"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("parsing start of blackout window %d: %w", i, err)
+		}
+		end, err := time.Parse(time.RFC3339, window["end"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("parsing end of blackout window %d: %w", i, err)
+		}
+		windows = append(windows, blackoutWindow{start: start, end: end, weekly: window["weekly"].(bool)})
+	}
+	return windows, nil
+}
+
+func (w blackoutWindow) validate() error {
+	if !w.end.After(w.start) {
+		return fmt.Errorf("end %s has to be after start %s", w.end.Format(time.RFC3339), w.start.Format(time.RFC3339))
+	}
+	if w.weekly && w.end.Sub(w.start) >= week {
+		return fmt.Errorf("weekly window has to be shorter than a week, got %s", w.end.Sub(w.start))
+	}
+	return nil
+}
+
+// active reports whether t is within the window. Weekly windows repeat every week from their start onwards.
+func (w blackoutWindow) active(t time.Time) bool {
+	if t.Before(w.start) {
+		return false
+	}
+	if !w.weekly {
+		return t.Before(w.end)
+	}
+	return t.Sub(w.start)%week < w.end.Sub(w.start)
+}
+
+// atToCron returns cron expression triggering at the given minute. It matches the same date every year,
+// the schedule is only reported as suspended after the first trigger.
+func atToCron(at time.Time) string {
+	at = at.UTC()
+	return fmt.Sprintf("%d %d %d %d *", at.Minute(), at.Hour(), at.Day(), int(at.Month()))
+}
+
+// scheduleCron returns cron expression of the schedule block, either configured directly or derived from one-shot trigger time.
+func scheduleCron(scheduleData map[string]any) (string, error) {
+	at, _ := scheduleData[FieldRebalancingScheduleAt].(string)
+	if at == "" {
+		return scheduleData["cron"].(string), nil
+	}
+	t, err := time.Parse(time.RFC3339, at)
+	if err != nil {
+		return "", fmt.Errorf("parsing %s: %w", FieldRebalancingScheduleAt, err)
+	}
+	return atToCron(t), nil
+}
+
+// oneShotTriggered reports whether the job of a one-shot schedule has already run, or should have run by now.
+func oneShotTriggered(at time.Time, job sdk.ScheduledrebalancingV1RebalancingJob, now time.Time) bool {
+	if job.LastTriggerAt != nil && !job.LastTriggerAt.Before(at) {
+		return true
+	}
+	return now.After(at.Add(oneShotGracePeriod))
+}
+
+func rebalancingScheduleWindowsDiff(d *schema.ResourceDiff, now time.Time) error {
+	if err := validateRebalancingScheduleAt(d, now); err != nil {
+		return err
+	}
+	if !d.NewValueKnown(FieldRebalancingScheduleBlackoutWindows) {
+		return nil
+	}
+
+	windows, err := toBlackoutWindows(d.Get(FieldRebalancingScheduleBlackoutWindows).([]any))
+	if err != nil {
+		return err
+	}
+
+	var result *multierror.Error
+	for i, w := range windows {
+		if err := w.validate(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("%s.%d: %w", FieldRebalancingScheduleBlackoutWindows, i, err))
+		}
+	}
+	return result.ErrorOrNil()
+}
+
+// validateRebalancingScheduleAt rejects one-shot trigger times in the past. Only changed values are checked,
+// so that schedules which already triggered keep planning cleanly.
+func validateRebalancingScheduleAt(d *schema.ResourceDiff, now time.Time) error {
+	path := "schedule.0." + FieldRebalancingScheduleAt
+	if !d.NewValueKnown(path) || !d.HasChange(path) {
+		return nil
+	}
+	v := d.Get(path).(string)
+	if v == "" {
+		return nil
+	}
+	at, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", FieldRebalancingScheduleAt, err)
+	}
+	if !at.After(now) {
+		return fmt.Errorf("%s: %s is in the past", path, v)
+	}
+	return nil
+}
+
+// rebalancingScheduleRestrictions returns blackout windows and one-shot trigger time of the schedule.
+func rebalancingScheduleRestrictions(d resourceProvider) ([]blackoutWindow, *time.Time, error) {
+	rawWindows, _ := d.GetOk(FieldRebalancingScheduleBlackoutWindows)
+	windowsData, _ := rawWindows.([]any)
+	windows, err := toBlackoutWindows(windowsData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v, ok := d.GetOk("schedule.0." + FieldRebalancingScheduleAt)
+	if !ok {
+		return windows, nil, nil
+	}
+	at, err := time.Parse(time.RFC3339, v.(string))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing %s: %w", FieldRebalancingScheduleAt, err)
+	}
+	return windows, &at, nil
+}
+
+// rebalancingScheduleSuspended reports whether jobs of the schedule have to be disabled at the given time,
+// i.e. a blackout window is active or the one-shot trigger has passed.
+func rebalancingScheduleSuspended(d resourceProvider, jobs []sdk.ScheduledrebalancingV1RebalancingJob, now time.Time) (bool, error) {
+	windows, at, err := rebalancingScheduleRestrictions(d)
+	if err != nil {
+		return false, err
+	}
+	if lo.ContainsBy(windows, func(w blackoutWindow) bool { return w.active(now) }) {
+		return true, nil
+	}
+	if at == nil {
+		return false, nil
+	}
+	return now.After(at.Add(oneShotGracePeriod)) || lo.ContainsBy(jobs, func(job sdk.ScheduledrebalancingV1RebalancingJob) bool {
+		return oneShotTriggered(*at, job, now)
+	}), nil
+}
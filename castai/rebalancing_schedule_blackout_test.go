@@ -0,0 +1,137 @@
+package castai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+)
+
+func TestBlackoutWindowActive(t *testing.T) {
+	// Black Friday freeze, Friday to Monday.
+	start := time.Date(2024, time.November, 29, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.December, 2, 0, 0, 0, 0, time.UTC)
+
+	tt := map[string]struct {
+		weekly bool
+		at     time.Time
+		active bool
+	}{
+		"before": {
+			at: start.Add(-time.Minute),
+		},
+		"at start": {
+			at:     start,
+			active: true,
+		},
+		"within": {
+			at:     start.Add(48 * time.Hour),
+			active: true,
+		},
+		"at end": {
+			at: end,
+		},
+		"week later": {
+			at: start.Add(week + time.Hour),
+		},
+		"weekly week later": {
+			weekly: true,
+			at:     start.Add(week + time.Hour),
+			active: true,
+		},
+		"weekly between occurrences": {
+			weekly: true,
+			at:     end.Add(24 * time.Hour),
+		},
+		"weekly before first occurrence": {
+			weekly: true,
+			at:     start.Add(-week + time.Hour),
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			w := blackoutWindow{start: start, end: end, weekly: tc.weekly}
+			require.Equal(t, tc.active, w.active(tc.at))
+		})
+	}
+}
+
+func TestBlackoutWindowValidate(t *testing.T) {
+	r := require.New(t)
+	start := time.Date(2024, time.November, 29, 0, 0, 0, 0, time.UTC)
+
+	r.NoError(blackoutWindow{start: start, end: start.Add(time.Hour), weekly: true}.validate())
+	r.EqualError(blackoutWindow{start: start, end: start}.validate(), "end 2024-11-29T00:00:00Z has to be after start 2024-11-29T00:00:00Z")
+	r.EqualError(blackoutWindow{start: start, end: start.Add(week), weekly: true}.validate(), "weekly window has to be shorter than a week, got 168h0m0s")
+}
+
+func TestScheduleCron(t *testing.T) {
+	r := require.New(t)
+
+	cron, err := scheduleCron(map[string]any{"cron": "0 12 * * *", FieldRebalancingScheduleAt: ""})
+	r.NoError(err)
+	r.Equal("0 12 * * *", cron)
+
+	cron, err = scheduleCron(map[string]any{"cron": "", FieldRebalancingScheduleAt: "2025-12-01T04:30:00+02:00"})
+	r.NoError(err)
+	r.Equal("30 2 1 12 *", cron)
+}
+
+func TestRebalancingScheduleSuspended(t *testing.T) {
+	blackoutStart := time.Date(2024, time.November, 29, 0, 0, 0, 0, time.UTC)
+	blackout := map[string]any{
+		"start":  blackoutStart.Format(time.RFC3339),
+		"end":    blackoutStart.Add(72 * time.Hour).Format(time.RFC3339),
+		"weekly": false,
+	}
+	at := time.Date(2025, time.December, 1, 2, 0, 0, 0, time.UTC)
+	oneShot := []any{map[string]any{FieldRebalancingScheduleAt: at.Format(time.RFC3339)}}
+
+	tt := map[string]struct {
+		raw      map[string]any
+		jobs     []sdk.ScheduledrebalancingV1RebalancingJob
+		now      time.Time
+		expected bool
+	}{
+		"during blackout": {
+			raw:      map[string]any{FieldRebalancingScheduleBlackoutWindows: []any{blackout}},
+			now:      blackoutStart.Add(time.Hour),
+			expected: true,
+		},
+		"after blackout": {
+			raw: map[string]any{FieldRebalancingScheduleBlackoutWindows: []any{blackout}},
+			now: blackoutStart.Add(96 * time.Hour),
+		},
+		"before one-shot trigger": {
+			raw: map[string]any{"schedule": oneShot},
+			now: at.Add(-time.Hour),
+		},
+		"one-shot triggered": {
+			raw:      map[string]any{"schedule": oneShot},
+			jobs:     []sdk.ScheduledrebalancingV1RebalancingJob{{Id: lo.ToPtr("job-1"), LastTriggerAt: lo.ToPtr(at.Add(time.Second))}},
+			now:      at.Add(time.Minute),
+			expected: true,
+		},
+		"one-shot past grace period without jobs": {
+			raw:      map[string]any{"schedule": oneShot},
+			now:      at.Add(2 * time.Hour),
+			expected: true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			d := schema.TestResourceDataRaw(t, resourceRebalancingSchedule().Schema, tc.raw)
+
+			suspended, err := rebalancingScheduleSuspended(d, tc.jobs, tc.now)
+			r.NoError(err)
+			r.Equal(tc.expected, suspended)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package castai
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/robfig/cron/v3"
+
+	// Time zones of CRON_TZ have to be resolvable regardless of the zoneinfo installed on the host running terraform.
+	_ "time/tzdata"
+)
+
+// cronParser accepts cron expressions as documented for rebalancing schedules: five fields, optionally prefixed
+// with CRON_TZ time zone. Descriptors like @daily or @every aren't accepted.
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// parseCron parses cron expression of rebalancing schedule. Expressions without CRON_TZ are interpreted in UTC.
+func parseCron(spec string) (*cron.SpecSchedule, error) {
+	spec = strings.TrimSpace(spec)
+	switch {
+	case spec == "":
+		return nil, fmt.Errorf("cron expression is empty")
+	case strings.HasPrefix(spec, "TZ="):
+		return nil, fmt.Errorf("time zone has to be set with CRON_TZ, e.g. CRON_TZ=America/New_York 0 12 * * ?")
+	case strings.HasPrefix(spec, "CRON_TZ="):
+		if !strings.Contains(spec, " ") {
+			return nil, fmt.Errorf("expected 5 fields after CRON_TZ")
+		}
+	default:
+		spec = "CRON_TZ=UTC " + spec
+	}
+
+	schedule, err := cronParser.Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	return schedule.(*cron.SpecSchedule), nil
+}
+
+// cronNextN returns up to n following trigger times of the schedule after t.
+func cronNextN(schedule cron.Schedule, t time.Time, n int) []time.Time {
+	result := make([]time.Time, 0, n)
+	for len(result) < n {
+		t = schedule.Next(t)
+		if t.IsZero() {
+			break
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+func validateCron() schema.SchemaValidateDiagFunc {
+	return func(v interface{}, path cty.Path) diag.Diagnostics {
+		value := v.(string)
+		if _, err := parseCron(value); err != nil {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "wrong value",
+				Detail:   fmt.Sprintf("%q is not a valid cron expression: %v", value, err),
+			}}
+		}
+		return nil
+	}
+}
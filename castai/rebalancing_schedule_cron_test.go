@@ -0,0 +1,85 @@
+package castai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	// Friday.
+	from := time.Date(2024, time.March, 8, 10, 30, 0, 0, time.UTC)
+
+	tt := map[string]struct {
+		cron     string
+		expected []string
+	}{
+		"daily with question mark": {
+			cron:     "0 12 * * ?",
+			expected: []string{"2024-03-08T12:00:00Z", "2024-03-09T12:00:00Z", "2024-03-10T12:00:00Z"},
+		},
+		"time zone with daylight saving time change": {
+			cron:     "CRON_TZ=America/New_York 0 12 * * ?",
+			expected: []string{"2024-03-08T17:00:00Z", "2024-03-09T17:00:00Z", "2024-03-10T16:00:00Z"},
+		},
+		"steps": {
+			cron:     "*/15 * * * *",
+			expected: []string{"2024-03-08T10:45:00Z", "2024-03-08T11:00:00Z", "2024-03-08T11:15:00Z"},
+		},
+		"day of month or day of week": {
+			cron:     "0 0 1,15 * 1",
+			expected: []string{"2024-03-11T00:00:00Z", "2024-03-15T00:00:00Z", "2024-03-18T00:00:00Z"},
+		},
+		"named weekdays": {
+			cron:     "0 22 * * MON-wed",
+			expected: []string{"2024-03-11T22:00:00Z", "2024-03-12T22:00:00Z", "2024-03-13T22:00:00Z"},
+		},
+		"leap day": {
+			cron:     "0 0 29 FEB *",
+			expected: []string{"2028-02-29T00:00:00Z", "2032-02-29T00:00:00Z", "2036-02-29T00:00:00Z"},
+		},
+		"never": {
+			cron:     "0 0 30 2 *",
+			expected: []string{},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+
+			schedule, err := parseCron(tc.cron)
+			r.NoError(err)
+
+			actual := lo.Map(cronNextN(schedule, from, 3), func(t time.Time, _ int) string {
+				return t.UTC().Format(time.RFC3339)
+			})
+			r.Equal(tc.expected, actual)
+		})
+	}
+}
+
+func TestParseCron_errors(t *testing.T) {
+	tt := map[string]string{
+		"0 12 * *":                        "expected exactly 5 fields, found 4: [0 12 * *]",
+		"61 * * * *":                      "end of range (61) above maximum (59): 61",
+		"0 12 * * 7":                      "end of range (7) above maximum (6): 7",
+		"0 5-1 * * *":                     "beginning of range (5) beyond end of range (1): 5-1",
+		"0 12 * JUNE *":                   `failed to parse int from JUNE: strconv.Atoi: parsing "JUNE": invalid syntax`,
+		"CRON_TZ=Mars/Olympus 0 12 * * *": "provided bad location Mars/Olympus: unknown time zone Mars/Olympus",
+		"CRON_TZ=UTC":                     "expected 5 fields after CRON_TZ",
+		"TZ=UTC 0 12 * * *":               "time zone has to be set with CRON_TZ, e.g. CRON_TZ=America/New_York 0 12 * * ?",
+		"@weekly":                         "parser does not accept descriptors: @weekly",
+		"@every 6h":                       "parser does not accept descriptors: @every 6h",
+		"  ":                              "cron expression is empty",
+	}
+
+	for cron, errMsg := range tt {
+		t.Run(cron, func(t *testing.T) {
+			_, err := parseCron(cron)
+			require.EqualError(t, err, errMsg)
+		})
+	}
+}
@@ -2,8 +2,10 @@ package reservations
 
 const (
 	FieldReservationsCSV            = "reservations_csv"
+	FieldReservationsCSVFormat      = "reservations_csv_format"
 	FieldReservationsOrganizationId = "organization_id"
 	FieldReservations               = "reservations"
+	FieldReservation                = "reservation"
 	FieldReservationName            = "name"
 	FieldReservationProvider        = "provider"
 	FieldReservationRegion          = "region"
@@ -15,6 +17,10 @@ const (
 	FieldReservationZoneId          = "zone_id"
 	FieldReservationZoneName        = "zone_name"
 
+	// Reservation resource fields, renamed as provider and count are reserved top level attribute names
+	FieldReservationCloudProvider = "cloud_provider"
+	FieldReservationInstanceCount = "instance_count"
+
 	// Azure specific fields
 	FieldReservationProductName           = "product_name"
 	FieldReservationQuantity              = "quantity"
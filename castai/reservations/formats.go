@@ -0,0 +1,328 @@
+package reservations
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+const (
+	CSVFormatCastAI = "castai"
+	CSVFormatAWSRI  = "aws_ri"
+	CSVFormatGCPCUD = "gcp_cud"
+	CSVFormatAzure  = "azure"
+)
+
+var CSVFormats = []string{CSVFormatCastAI, CSVFormatAWSRI, CSVFormatGCPCUD, CSVFormatAzure}
+
+// csvFormat describes reservations export of a cloud provider.
+type csvFormat struct {
+	// provider of all the reservations in the export.
+	provider string
+	// columnAliases maps reservation fields to normalized column names of the export, in order of preference.
+	columnAliases map[string][]string
+	// statusColumns are checked for the reservation status, records with other statuses than activeStatuses are skipped.
+	statusColumns  []string
+	activeStatuses []string
+	// dateLayouts are tried in order when parsing dates of the export.
+	dateLayouts []string
+	// normalizeRegion maps region of the record to the name used by the provider API.
+	normalizeRegion func(resource ReservationResource) *string
+	// zeroPriceUnknown leaves price unset when it is zero in the export, as the reservation was paid upfront
+	// and its hourly price isn't known.
+	zeroPriceUnknown bool
+	// mapColumns fills reservation fields derived from columns of the export which don't map to a single field.
+	mapColumns func(columns, record []string, resource ReservationResource) error
+}
+
+var awsAvailabilityZoneRegexp = regexp.MustCompile(`^([a-z]{2}(-gov)?-[a-z]+-\d+)[a-z]$`)
+
+var csvFormats = map[string]csvFormat{
+	// Reserved Instances exported from EC2 console or `aws ec2 describe-reserved-instances`.
+	CSVFormatAWSRI: {
+		provider: "aws",
+		columnAliases: map[string][]string{
+			FieldReservationName:         {"reserved_instance_id", "reserved_instances_id", "id"},
+			FieldReservationRegion:       {"region"},
+			FieldReservationInstanceType: {"instance_type"},
+			FieldReservationPrice:        {"usage_price", "hourly_price"},
+			FieldReservationCount:        {"instance_count", "count"},
+			FieldReservationStartDate:    {"start", "start_date"},
+			FieldReservationEndDate:      {"expires", "end", "end_date"},
+			FieldReservationZoneName:     {"availability_zone"},
+		},
+		statusColumns:    []string{"state"},
+		activeStatuses:   []string{"active"},
+		dateLayouts:      []string{time.RFC3339, "2006-01-02 15:04:05 MST", "2006-01-02 15:04:05", "2006-01-02"},
+		zeroPriceUnknown: true,
+		normalizeRegion: func(resource ReservationResource) *string {
+			if region := resource[FieldReservationRegion]; region != nil {
+				return region
+			}
+			// Zonal reservations have availability zone only, e.g. us-east-1a.
+			matches := awsAvailabilityZoneRegexp.FindStringSubmatch(lo.FromPtr(resource[FieldReservationZoneName]))
+			if matches == nil {
+				return nil
+			}
+			return lo.ToPtr(matches[1])
+		},
+	},
+	// Commitments exported with `gcloud compute commitments list`, see mapGCPCommitmentResources for the used columns.
+	CSVFormatGCPCUD: {
+		provider: "gcp",
+		columnAliases: map[string][]string{
+			FieldReservationName:      {"name"},
+			FieldReservationRegion:    {"region"},
+			FieldReservationStartDate: {"start_timestamp", "starttimestamp"},
+			FieldReservationEndDate:   {"end_timestamp", "endtimestamp"},
+		},
+		statusColumns:  []string{"status"},
+		activeStatuses: []string{"active"},
+		dateLayouts:    []string{time.RFC3339},
+		mapColumns:     mapGCPCommitmentResources,
+		normalizeRegion: func(resource ReservationResource) *string {
+			// Regions are given as resource URLs, e.g. https://www.googleapis.com/compute/v1/projects/p/regions/us-central1.
+			region := resource[FieldReservationRegion]
+			if region == nil {
+				return nil
+			}
+			return lo.ToPtr(path.Base(*region))
+		},
+	},
+	// Reservations exported from Azure portal.
+	CSVFormatAzure: {
+		provider: "azure",
+		columnAliases: map[string][]string{
+			FieldReservationName:                  {"name"},
+			FieldReservationRegion:                {"region", "location"},
+			FieldReservationInstanceType:          {"product_name", "sku"},
+			FieldReservationCount:                 {"quantity"},
+			FieldReservationStartDate:             {"purchase_date"},
+			FieldReservationEndDate:               {"expiration_date"},
+			FieldReservationProductName:           {"product_name", "sku"},
+			FieldReservationQuantity:              {"quantity"},
+			FieldReservationPurchaseDate:          {"purchase_date"},
+			FieldReservationExpirationDate:        {"expiration_date"},
+			FieldReservationType:                  {"type"},
+			FieldReservationDeepLinkToReservation: {"deep_link_to_reservation"},
+		},
+		statusColumns:  []string{"status"},
+		activeStatuses: []string{"succeeded"},
+		dateLayouts:    []string{time.RFC3339, "1/2/2006 3:04:05 PM", "1/2/2006 15:04", "1/2/2006", "2006-01-02"},
+		normalizeRegion: func(resource ReservationResource) *string {
+			// Portal uses display names of regions, e.g. East US for eastus.
+			region := resource[FieldReservationRegion]
+			if region == nil {
+				return nil
+			}
+			return lo.ToPtr(strings.ToLower(strings.ReplaceAll(*region, " ", "")))
+		},
+	},
+}
+
+// MapCsvRecordsInFormatToReservationResources maps records of reservations export in the given format, see CSVFormats.
+func MapCsvRecordsInFormatToReservationResources(format string, csvRecords [][]string) ([]*ReservationResource, error) {
+	if format == "" || format == CSVFormatCastAI {
+		return MapCsvRecordsToReservationResources(csvRecords)
+	}
+
+	csvFormat, found := csvFormats[format]
+	if !found {
+		return nil, fmt.Errorf("unsupported reservations csv format %q, supported formats: %s", format, strings.Join(CSVFormats, ", "))
+	}
+	if len(csvRecords) == 0 {
+		return []*ReservationResource{}, nil
+	}
+
+	columns := normalizeCsvColumnNames(csvRecords[0])
+	fieldIndexes := mapReservationsHeaderToReservationFieldIndexes(columns, csvFormat.columnAliases)
+	statusIndex := -1
+	if _, index, found := lo.FindIndexOf(columns, func(column string) bool { return lo.Contains(csvFormat.statusColumns, column) }); found {
+		statusIndex = index
+	}
+
+	reservations := make([]*ReservationResource, 0, len(csvRecords)-1)
+	for _, record := range csvRecords[1:] {
+		if statusIndex != -1 && !lo.Contains(csvFormat.activeStatuses, strings.ToLower(record[statusIndex])) {
+			continue
+		}
+
+		result, err := csvFormat.mapRecordToReservationResource(columns, fieldIndexes, record)
+		if err != nil {
+			return nil, fmt.Errorf("reservation %v: %w", record, err)
+		}
+
+		reservations = append(reservations, result)
+	}
+	return reservations, nil
+}
+
+func (f csvFormat) mapRecordToReservationResource(columns []string, fieldIndexes map[string]int, record []string) (*ReservationResource, error) {
+	result := ReservationResource{}
+	for _, field := range reservationResourceFields {
+		result[field] = nil
+		if value := strings.TrimSpace(lo.FromPtr(getRecordFieldStringValue(field, fieldIndexes, record))); value != "" {
+			result[field] = lo.ToPtr(value)
+		}
+	}
+	result[FieldReservationProvider] = lo.ToPtr(f.provider)
+	result[FieldReservationRegion] = f.normalizeRegion(result)
+	if f.mapColumns != nil {
+		if err := f.mapColumns(columns, record, result); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, field := range []string{FieldReservationStartDate, FieldReservationEndDate, FieldReservationPurchaseDate, FieldReservationExpirationDate} {
+		date, err := f.parseDate(result[field])
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", field, err)
+		}
+		result[field] = date
+	}
+
+	for _, field := range []string{FieldReservationCount, FieldReservationQuantity} {
+		count, err := parseCount(result[field])
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", field, err)
+		}
+		result[field] = count
+	}
+
+	price, err := parsePrice(result[FieldReservationPrice])
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", FieldReservationPrice, err)
+	}
+	if f.zeroPriceUnknown && lo.FromPtr(price) == "0" {
+		price = nil
+	}
+	result[FieldReservationPrice] = price
+
+	for _, field := range []string{FieldReservationName, FieldReservationRegion, FieldReservationInstanceType, FieldReservationCount, FieldReservationStartDate} {
+		if lo.FromPtr(result[field]) == "" {
+			return nil, fmt.Errorf("%s is missing", field)
+		}
+	}
+
+	return &result, nil
+}
+
+// parseDate converts date of the export to RFC 3339 format in UTC.
+func (f csvFormat) parseDate(value *string) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	for _, layout := range f.dateLayouts {
+		if parsed, err := time.Parse(layout, *value); err == nil {
+			return lo.ToPtr(parsed.UTC().Format(time.RFC3339)), nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported date %q", *value)
+}
+
+func parseCount(value *string) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	count, err := strconv.Atoi(strings.ReplaceAll(*value, ",", ""))
+	if err != nil || count < 0 {
+		return nil, fmt.Errorf("invalid count %q", *value)
+	}
+	return lo.ToPtr(strconv.Itoa(count)), nil
+}
+
+// parsePrice strips currency from prices of the export, e.g. $0.034 or USD 0.034.
+func parsePrice(value *string) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	trimmed := strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(*value, "USD"), "$"))
+	price, err := strconv.ParseFloat(trimmed, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price %q", *value)
+	}
+	return lo.ToPtr(strconv.FormatFloat(price, 'f', -1, 64)), nil
+}
+
+const (
+	gcpCommitmentColumnType            = "type"
+	gcpCommitmentColumnResourceTypes   = "resource_types"
+	gcpCommitmentColumnResourceAmounts = "resource_amounts"
+
+	gcpCommitmentResourceVCPU   = "VCPU"
+	gcpCommitmentResourceMemory = "MEMORY"
+)
+
+var (
+	// gcpCommitmentTypeSeries maps commitment types which don't name the machine series, other types end with it, e.g. GENERAL_PURPOSE_N2.
+	gcpCommitmentTypeSeries = map[string]string{
+		"":                  "n1",
+		"GENERAL_PURPOSE":   "n1",
+		"COMPUTE_OPTIMIZED": "c2",
+		"MEMORY_OPTIMIZED":  "m1",
+	}
+	// gcpCustomMachineTypePrefixes lists machine series with custom machine types, N1 ones have no series prefix.
+	gcpCustomMachineTypePrefixes = map[string]string{
+		"n1":  "custom",
+		"n2":  "n2-custom",
+		"n2d": "n2d-custom",
+		"n4":  "n4-custom",
+		"e2":  "e2-custom",
+	}
+)
+
+// mapGCPCommitmentResources maps resources of a commitment to a single custom machine type holding all committed vCPUs and
+// memory, e.g. 8 vCPUs and 32768 MB of memory of GENERAL_PURPOSE_N2 commitment becomes one n2-custom-8-32768 instance.
+// Resources are read from `resource_types` and `resource_amounts` columns, holding `resources[].type` and `resources[].amount`
+// of the commitment separated by semicolons. Other resources than vCPUs and memory, e.g. local SSDs, are ignored.
+func mapGCPCommitmentResources(columns, record []string, resource ReservationResource) error {
+	column := func(name string) string {
+		if _, index, found := lo.FindIndexOf(columns, func(column string) bool { return column == name }); found && index < len(record) {
+			return strings.TrimSpace(record[index])
+		}
+		return ""
+	}
+
+	commitmentType := strings.ToUpper(column(gcpCommitmentColumnType))
+	series, found := gcpCommitmentTypeSeries[commitmentType]
+	if !found {
+		series = strings.ToLower(commitmentType[strings.LastIndex(commitmentType, "_")+1:])
+	}
+	prefix, found := gcpCustomMachineTypePrefixes[series]
+	if !found {
+		return fmt.Errorf("commitment type %q can't be mapped to a custom machine type, provide the commitment as a reservation block instead", commitmentType)
+	}
+
+	types := strings.Split(column(gcpCommitmentColumnResourceTypes), ";")
+	amounts := strings.Split(column(gcpCommitmentColumnResourceAmounts), ";")
+	if len(types) != len(amounts) {
+		return fmt.Errorf("%s and %s columns have different number of items", gcpCommitmentColumnResourceTypes, gcpCommitmentColumnResourceAmounts)
+	}
+	committed := make(map[string]int, len(types))
+	for i, resourceType := range types {
+		resourceType = strings.ToUpper(strings.TrimSpace(resourceType))
+		if resourceType != gcpCommitmentResourceVCPU && resourceType != gcpCommitmentResourceMemory {
+			continue
+		}
+		amount, err := strconv.Atoi(strings.TrimSpace(amounts[i]))
+		if err != nil || amount <= 0 {
+			return fmt.Errorf("invalid %s amount %q", resourceType, amounts[i])
+		}
+		committed[resourceType] += amount
+	}
+	if committed[gcpCommitmentResourceVCPU] == 0 || committed[gcpCommitmentResourceMemory] == 0 {
+		return fmt.Errorf("commitment has no %s and %s resources", gcpCommitmentResourceVCPU, gcpCommitmentResourceMemory)
+	}
+
+	resource[FieldReservationInstanceType] = lo.ToPtr(fmt.Sprintf("%s-%d-%d", prefix, committed[gcpCommitmentResourceVCPU], committed[gcpCommitmentResourceMemory]))
+	resource[FieldReservationCount] = lo.ToPtr("1")
+	return nil
+}
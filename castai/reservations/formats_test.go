@@ -0,0 +1,225 @@
+package reservations
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func readCsvRecords(t *testing.T, name string) [][]string {
+	t.Helper()
+
+	file, err := os.Open(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	require.NoError(t, err)
+	return records
+}
+
+func TestMapCsvRecordsInFormatToReservationResources(t *testing.T) {
+	tests := map[string]struct {
+		format string
+		file   string
+		want   []*ReservationResource
+	}{
+		"should map AWS reserved instances export": {
+			format: CSVFormatAWSRI,
+			file:   "aws_ri.csv",
+			want: []*ReservationResource{
+				{
+					FieldReservationName:                  lo.ToPtr("4b2a1c3d-9e8f-4a7b-8c6d-5e4f3a2b1c0d"),
+					FieldReservationProvider:              lo.ToPtr("aws"),
+					FieldReservationRegion:                lo.ToPtr("eu-central-1"),
+					FieldReservationInstanceType:          lo.ToPtr("m5.xlarge"),
+					FieldReservationPrice:                 nil,
+					FieldReservationCount:                 lo.ToPtr("4"),
+					FieldReservationStartDate:             lo.ToPtr("2023-03-01T10:15:00Z"),
+					FieldReservationEndDate:               lo.ToPtr("2026-03-01T10:14:59Z"),
+					FieldReservationZoneId:                nil,
+					FieldReservationZoneName:              nil,
+					FieldReservationProductName:           nil,
+					FieldReservationQuantity:              nil,
+					FieldReservationPurchaseDate:          nil,
+					FieldReservationExpirationDate:        nil,
+					FieldReservationType:                  nil,
+					FieldReservationDeepLinkToReservation: nil,
+				},
+				{
+					FieldReservationName:                  lo.ToPtr("7d6c5b4a-3f2e-4d1c-9b8a-7f6e5d4c3b2a"),
+					FieldReservationProvider:              lo.ToPtr("aws"),
+					FieldReservationRegion:                lo.ToPtr("eu-central-1"),
+					FieldReservationInstanceType:          lo.ToPtr("c5.2xlarge"),
+					FieldReservationPrice:                 lo.ToPtr("0.264"),
+					FieldReservationCount:                 lo.ToPtr("2"),
+					FieldReservationStartDate:             lo.ToPtr("2024-01-15T08:00:00Z"),
+					FieldReservationEndDate:               lo.ToPtr("2025-01-15T07:59:59Z"),
+					FieldReservationZoneId:                nil,
+					FieldReservationZoneName:              lo.ToPtr("eu-central-1b"),
+					FieldReservationProductName:           nil,
+					FieldReservationQuantity:              nil,
+					FieldReservationPurchaseDate:          nil,
+					FieldReservationExpirationDate:        nil,
+					FieldReservationType:                  nil,
+					FieldReservationDeepLinkToReservation: nil,
+				},
+			},
+		},
+		"should map GCP commitments export": {
+			format: CSVFormatGCPCUD,
+			file:   "gcp_cud.csv",
+			want: []*ReservationResource{
+				{
+					FieldReservationName:                  lo.ToPtr("commitment-n2-prod"),
+					FieldReservationProvider:              lo.ToPtr("gcp"),
+					FieldReservationRegion:                lo.ToPtr("us-central1"),
+					FieldReservationInstanceType:          lo.ToPtr("n2-custom-48-196608"),
+					FieldReservationPrice:                 nil,
+					FieldReservationCount:                 lo.ToPtr("1"),
+					FieldReservationStartDate:             lo.ToPtr("2024-02-01T08:00:00Z"),
+					FieldReservationEndDate:               lo.ToPtr("2025-02-01T08:00:00Z"),
+					FieldReservationZoneId:                nil,
+					FieldReservationZoneName:              nil,
+					FieldReservationProductName:           nil,
+					FieldReservationQuantity:              nil,
+					FieldReservationPurchaseDate:          nil,
+					FieldReservationExpirationDate:        nil,
+					FieldReservationType:                  nil,
+					FieldReservationDeepLinkToReservation: nil,
+				},
+				{
+					FieldReservationName:                  lo.ToPtr("commitment-e2-batch"),
+					FieldReservationProvider:              lo.ToPtr("gcp"),
+					FieldReservationRegion:                lo.ToPtr("europe-west4"),
+					FieldReservationInstanceType:          lo.ToPtr("e2-custom-16-65536"),
+					FieldReservationPrice:                 nil,
+					FieldReservationCount:                 lo.ToPtr("1"),
+					FieldReservationStartDate:             lo.ToPtr("2023-09-10T07:00:00Z"),
+					FieldReservationEndDate:               lo.ToPtr("2026-09-10T07:00:00Z"),
+					FieldReservationZoneId:                nil,
+					FieldReservationZoneName:              nil,
+					FieldReservationProductName:           nil,
+					FieldReservationQuantity:              nil,
+					FieldReservationPurchaseDate:          nil,
+					FieldReservationExpirationDate:        nil,
+					FieldReservationType:                  nil,
+					FieldReservationDeepLinkToReservation: nil,
+				},
+			},
+		},
+		"should map Azure reservations export": {
+			format: CSVFormatAzure,
+			file:   "azure.csv",
+			want: []*ReservationResource{
+				{
+					FieldReservationName:                  lo.ToPtr("VM_RI_03-14-2024_09-21"),
+					FieldReservationProvider:              lo.ToPtr("azure"),
+					FieldReservationRegion:                lo.ToPtr("westeurope"),
+					FieldReservationInstanceType:          lo.ToPtr("Standard_D4s_v5"),
+					FieldReservationPrice:                 nil,
+					FieldReservationCount:                 lo.ToPtr("5"),
+					FieldReservationStartDate:             lo.ToPtr("2024-03-14T00:00:00Z"),
+					FieldReservationEndDate:               lo.ToPtr("2027-03-14T00:00:00Z"),
+					FieldReservationZoneId:                nil,
+					FieldReservationZoneName:              nil,
+					FieldReservationProductName:           lo.ToPtr("Standard_D4s_v5"),
+					FieldReservationQuantity:              lo.ToPtr("5"),
+					FieldReservationPurchaseDate:          lo.ToPtr("2024-03-14T00:00:00Z"),
+					FieldReservationExpirationDate:        lo.ToPtr("2027-03-14T00:00:00Z"),
+					FieldReservationType:                  lo.ToPtr("VirtualMachines"),
+					FieldReservationDeepLinkToReservation: lo.ToPtr("https://portal.azure.com#resource/providers/microsoft.capacity/reservationOrders/1a2b3c4d-5e6f-4a70-8b9c-0d1e2f3a4b5c/reservations/0f1e2d3c-4b5a-4968-8776-655443322110/overview"),
+				},
+				{
+					FieldReservationName:                  lo.ToPtr("VM_RI_11-02-2023_14-05"),
+					FieldReservationProvider:              lo.ToPtr("azure"),
+					FieldReservationRegion:                lo.ToPtr("eastus2"),
+					FieldReservationInstanceType:          lo.ToPtr("Standard_E8as_v5"),
+					FieldReservationPrice:                 nil,
+					FieldReservationCount:                 lo.ToPtr("2"),
+					FieldReservationStartDate:             lo.ToPtr("2023-11-02T14:05:00Z"),
+					FieldReservationEndDate:               lo.ToPtr("2024-11-02T14:05:00Z"),
+					FieldReservationZoneId:                nil,
+					FieldReservationZoneName:              nil,
+					FieldReservationProductName:           lo.ToPtr("Standard_E8as_v5"),
+					FieldReservationQuantity:              lo.ToPtr("2"),
+					FieldReservationPurchaseDate:          lo.ToPtr("2023-11-02T14:05:00Z"),
+					FieldReservationExpirationDate:        lo.ToPtr("2024-11-02T14:05:00Z"),
+					FieldReservationType:                  lo.ToPtr("VirtualMachines"),
+					FieldReservationDeepLinkToReservation: lo.ToPtr("https://portal.azure.com#resource/providers/microsoft.capacity/reservationOrders/2b3c4d5e-6f70-4a81-9c0d-1e2f3a4b5c6d/reservations/9a8b7c6d-5e4f-4321-8fed-cba987654321/overview"),
+				},
+			},
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			r := require.New(t)
+
+			got, err := MapCsvRecordsInFormatToReservationResources(tt.format, readCsvRecords(t, tt.file))
+
+			r.NoError(err)
+			r.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestMapCsvRecordsInFormatToReservationResources_errors(t *testing.T) {
+	tests := map[string]struct {
+		format                   string
+		csvRecords               [][]string
+		expectErrMessageContains string
+	}{
+		"should return an error for unknown format": {
+			format:                   "oracle",
+			expectErrMessageContains: `unsupported reservations csv format "oracle"`,
+		},
+		"should return an error for unsupported date": {
+			format: CSVFormatAWSRI,
+			csvRecords: [][]string{
+				{"Reserved Instance ID", "Instance Type", "Region", "Instance Count", "Start"},
+				{"ri-1", "m5.large", "us-east-1", "1", "March 1st"},
+			},
+			expectErrMessageContains: `parsing start_date: unsupported date "March 1st"`,
+		},
+		"should return an error for invalid count": {
+			format: CSVFormatAWSRI,
+			csvRecords: [][]string{
+				{"Reserved Instance ID", "Instance Type", "Region", "Instance Count", "Start"},
+				{"ri-1", "m5.large", "us-east-1", "two", "2024-01-01"},
+			},
+			expectErrMessageContains: `parsing count: invalid count "two"`,
+		},
+		"should return an error for commitments of series without custom machine types": {
+			format: CSVFormatGCPCUD,
+			csvRecords: [][]string{
+				{"name", "region", "status", "type", "start_timestamp", "resource_types", "resource_amounts"},
+				{"commitment-1", "us-central1", "ACTIVE", "COMPUTE_OPTIMIZED_C2D", "2024-01-01T00:00:00Z", "VCPU;MEMORY", "8;32768"},
+			},
+			expectErrMessageContains: `commitment type "COMPUTE_OPTIMIZED_C2D" can't be mapped to a custom machine type`,
+		},
+		"should return an error for commitments without vCPUs and memory": {
+			format: CSVFormatGCPCUD,
+			csvRecords: [][]string{
+				{"name", "region", "status", "type", "start_timestamp", "resource_types", "resource_amounts"},
+				{"commitment-1", "us-central1", "ACTIVE", "GENERAL_PURPOSE_N2", "2024-01-01T00:00:00Z", "LOCAL_SSD", "375"},
+			},
+			expectErrMessageContains: "commitment has no VCPU and MEMORY resources",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			r := require.New(t)
+
+			_, err := MapCsvRecordsInFormatToReservationResources(tt.format, tt.csvRecords)
+
+			r.Error(err)
+			r.Contains(err.Error(), tt.expectErrMessageContains)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package reservations
 import (
 	"fmt"
 	"github.com/castai/terraform-provider-castai/castai/sdk"
+	"github.com/hashicorp/go-multierror"
 	"github.com/samber/lo"
 	"strconv"
 	"strings"
@@ -16,12 +17,10 @@ func MapCsvRecordsToReservationResources(csvRecords [][]string) ([]*ReservationR
 	if len(csvRecords) > 0 {
 		csvColumns = csvRecords[0]
 	}
-	normalizedCsvColumnNames := lo.Map(csvColumns, func(column string, _ int) string {
-		return strings.ToLower(strings.ReplaceAll(column, " ", "_"))
-	})
+	normalizedCsvColumnNames := normalizeCsvColumnNames(csvColumns)
 
 	reservationRecords := csvRecords[1:]
-	fieldIndexes := mapReservationsHeaderToReservationFieldIndexes(normalizedCsvColumnNames)
+	fieldIndexes := mapReservationsHeaderToReservationFieldIndexes(normalizedCsvColumnNames, csvColumnAlias)
 
 	reservations := make([]*ReservationResource, 0, len(reservationRecords))
 	for _, record := range reservationRecords {
@@ -88,11 +87,82 @@ func MapToReservationResourcesWithCommonFieldsOnly(reservationResources []*Reser
 	})
 }
 
-func mapReservationsHeaderToReservationFieldIndexes(columns []string) map[string]int {
+// MapReservationBlockToReservationResource maps a `reservation` block to reservation resource, formatting typed values
+// the same way they are given in CSV. Empty values are omitted.
+func MapReservationBlockToReservationResource(block map[string]any) *ReservationResource {
+	result := ReservationResource{}
+	for _, field := range []string{
+		FieldReservationName,
+		FieldReservationProvider,
+		FieldReservationRegion,
+		FieldReservationInstanceType,
+		FieldReservationStartDate,
+		FieldReservationEndDate,
+		FieldReservationZoneId,
+		FieldReservationZoneName,
+	} {
+		result[field] = nil
+		if value, _ := block[field].(string); value != "" {
+			result[field] = lo.ToPtr(value)
+		}
+	}
+
+	result[FieldReservationPrice] = nil
+	if price, _ := block[FieldReservationPrice].(float64); price != 0 {
+		result[FieldReservationPrice] = lo.ToPtr(strconv.FormatFloat(price, 'f', -1, 64))
+	}
+	result[FieldReservationCount] = nil
+	if count, _ := block[FieldReservationCount].(int); count != 0 {
+		result[FieldReservationCount] = lo.ToPtr(strconv.Itoa(count))
+	}
+
+	return &result
+}
+
+// ValidateUniqueReservations returns an error for every reservation name repeated for the same provider, region and instance type.
+// Reservations without a name are not checked.
+func ValidateUniqueReservations(reservationResources []*ReservationResource) error {
+	var result *multierror.Error
+
+	seen := make(map[string]struct{}, len(reservationResources))
+	for _, item := range reservationResources {
+		resource := *item
+		if lo.FromPtr(resource[FieldReservationName]) == "" {
+			continue
+		}
+		key := strings.Join([]string{
+			lo.FromPtr(resource[FieldReservationProvider]),
+			lo.FromPtr(resource[FieldReservationRegion]),
+			lo.FromPtr(resource[FieldReservationInstanceType]),
+			lo.FromPtr(resource[FieldReservationName]),
+		}, "/")
+
+		if _, found := seen[key]; found {
+			result = multierror.Append(result, fmt.Errorf("duplicate reservation %q of instance type %q in %s region %q",
+				lo.FromPtr(resource[FieldReservationName]),
+				lo.FromPtr(resource[FieldReservationInstanceType]),
+				lo.FromPtr(resource[FieldReservationProvider]),
+				lo.FromPtr(resource[FieldReservationRegion]),
+			))
+			continue
+		}
+		seen[key] = struct{}{}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func normalizeCsvColumnNames(columns []string) []string {
+	return lo.Map(columns, func(column string, _ int) string {
+		return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")), " ", "_"))
+	})
+}
+
+func mapReservationsHeaderToReservationFieldIndexes(columns []string, columnAliases map[string][]string) map[string]int {
 	indexes := make(map[string]int, len(reservationResourceFields))
 	for _, field := range reservationResourceFields {
 		index := -1
-		aliases := csvColumnAlias[field]
+		aliases := columnAliases[field]
 		for _, alias := range aliases {
 			_, fieldIdx, found := lo.FindIndexOf(columns, func(item string) bool {
 				return strings.ToLower(item) == alias
@@ -335,7 +335,7 @@ func Test_mapReservationsHeaderToReservationFieldIndexes(t *testing.T) {
 		t.Run(testName, func(t *testing.T) {
 			r := require.New(t)
 
-			got := mapReservationsHeaderToReservationFieldIndexes(tt.args.columns)
+			got := mapReservationsHeaderToReservationFieldIndexes(tt.args.columns, csvColumnAlias)
 
 			r.Equal(tt.want, got)
 		})
@@ -412,3 +412,75 @@ func Test_getRecordReservationProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestMapReservationBlockToReservationResource(t *testing.T) {
+	r := require.New(t)
+
+	got := MapReservationBlockToReservationResource(map[string]any{
+		FieldReservationName:         "reservation1",
+		FieldReservationProvider:     "aws",
+		FieldReservationRegion:       "us-east-1",
+		FieldReservationInstanceType: "c5n.large",
+		FieldReservationPrice:        0.125,
+		FieldReservationCount:        3,
+		FieldReservationStartDate:    "2020-01-01T00:00:00Z",
+		FieldReservationEndDate:      "",
+		FieldReservationZoneId:       "",
+		FieldReservationZoneName:     "us-east-1a",
+	})
+
+	r.Equal(&ReservationResource{
+		FieldReservationName:         lo.ToPtr("reservation1"),
+		FieldReservationProvider:     lo.ToPtr("aws"),
+		FieldReservationRegion:       lo.ToPtr("us-east-1"),
+		FieldReservationInstanceType: lo.ToPtr("c5n.large"),
+		FieldReservationPrice:        lo.ToPtr("0.125"),
+		FieldReservationCount:        lo.ToPtr("3"),
+		FieldReservationStartDate:    lo.ToPtr("2020-01-01T00:00:00Z"),
+		FieldReservationEndDate:      nil,
+		FieldReservationZoneId:       nil,
+		FieldReservationZoneName:     lo.ToPtr("us-east-1a"),
+	}, got)
+}
+
+func TestValidateUniqueReservations(t *testing.T) {
+	reservation := func(name, region string) *ReservationResource {
+		return &ReservationResource{
+			FieldReservationName:         lo.ToPtr(name),
+			FieldReservationProvider:     lo.ToPtr("aws"),
+			FieldReservationRegion:       lo.ToPtr(region),
+			FieldReservationInstanceType: lo.ToPtr("c5n.large"),
+		}
+	}
+
+	tests := map[string]struct {
+		reservations             []*ReservationResource
+		expectErrMessageContains *string
+	}{
+		"should accept same name in different regions": {
+			reservations: []*ReservationResource{reservation("reservation1", "us-east-1"), reservation("reservation1", "eu-central-1")},
+		},
+		"should accept repeated empty name": {
+			reservations: []*ReservationResource{reservation("", "us-east-1"), reservation("", "us-east-1")},
+		},
+		"should return an error for repeated name": {
+			reservations:             []*ReservationResource{reservation("reservation1", "us-east-1"), reservation("reservation2", "us-east-1"), reservation("reservation1", "us-east-1")},
+			expectErrMessageContains: lo.ToPtr(`duplicate reservation "reservation1" of instance type "c5n.large" in aws region "us-east-1"`),
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			r := require.New(t)
+
+			err := ValidateUniqueReservations(tt.reservations)
+
+			if tt.expectErrMessageContains != nil {
+				r.Error(err)
+				r.Contains(err.Error(), *tt.expectErrMessageContains)
+			} else {
+				r.NoError(err)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/castai/terraform-provider-castai/castai/sdk"
+	castval "github.com/castai/terraform-provider-castai/castai/validation"
 )
 
 const (
@@ -57,25 +58,25 @@ func resourceAKSCluster() *schema.Resource {
 			FieldAKSClusterSubscriptionID: {
 				Type:             schema.TypeString,
 				Required:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+				ValidateDiagFunc: castval.All(validation.ToDiagFunc(validation.StringIsNotWhiteSpace), castval.Warning(castval.ValidUUID())),
 				Description:      "ID of the Azure subscription.",
 			},
 			FieldAKSClusterNodeResourceGroup: {
 				Type:             schema.TypeString,
 				Required:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+				ValidateDiagFunc: castval.All(validation.ToDiagFunc(validation.StringIsNotWhiteSpace), castval.Warning(castval.ValidAzureResourceGroupName())),
 				Description:      "Azure resource group in which nodes are and will be created.",
 			},
 			FieldAKSClusterTenantID: {
 				Type:             schema.TypeString,
 				Required:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+				ValidateDiagFunc: castval.All(validation.ToDiagFunc(validation.StringIsNotWhiteSpace), castval.Warning(castval.ValidUUID())),
 				Description:      "Azure AD tenant ID from the used subscription.",
 			},
 			FieldAKSClusterClientID: {
 				Type:             schema.TypeString,
 				Required:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+				ValidateDiagFunc: castval.All(validation.ToDiagFunc(validation.StringIsNotWhiteSpace), castval.Warning(castval.ValidUUID())),
 				Description:      "Azure AD application ID that is created and used by CAST AI.",
 			},
 			FieldAKSClusterClientSecret: {
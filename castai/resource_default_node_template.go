@@ -0,0 +1,217 @@
+package castai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+)
+
+const FieldDefaultNodeTemplateOriginalSettings = "original_settings"
+
+func resourceDefaultNodeTemplate() *schema.Resource {
+	// Default node template shares its settings with regular node templates, except for the identity which is
+	// assigned by CAST AI when the cluster is onboarded.
+	s := resourceNodeTemplate().Schema
+	delete(s, FieldNodeTemplateOnDestroy)
+	s[FieldClusterId] = &schema.Schema{
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IsUUID),
+		Description:      "CAST AI cluster id.",
+	}
+	s[FieldNodeTemplateName] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Name of the default node template.",
+	}
+	s[FieldNodeTemplateIsDefault] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Flag whether the node template is still the default one of the cluster.",
+	}
+	s[FieldDefaultNodeTemplateOriginalSettings] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Settings of the default node template before it was adopted, in JSON format. They are restored on destroy.",
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceDefaultNodeTemplateCreate,
+		ReadContext:   resourceNodeTemplateRead,
+		UpdateContext: resourceNodeTemplateUpdate,
+		DeleteContext: resourceDefaultNodeTemplateDelete,
+		CustomizeDiff: nodeTemplateConstraintsDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: defaultNodeTemplateStateImporter,
+		},
+		Description: "CAST AI default node template resource to manage the node template created by CAST AI for every cluster. " +
+			"The existing default node template is adopted on create and its previous settings are restored on destroy.",
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+
+		Schema: s,
+	}
+}
+
+func resourceDefaultNodeTemplateCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ProviderConfig).api
+	clusterID := d.Get(FieldClusterID).(string)
+
+	// Default node template is created in the background after the cluster is onboarded.
+	var nodeTemplate *sdk.NodetemplatesV1NodeTemplate
+	if err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate)-5*time.Second, func() *retry.RetryError {
+		t, err := getDefaultNodeTemplate(ctx, client, clusterID)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		if t == nil {
+			return retry.RetryableError(fmt.Errorf("default node template for cluster %q not found", clusterID))
+		}
+		nodeTemplate = t
+		return nil
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := lo.FromPtr(nodeTemplate.Name)
+	log.Printf("[INFO] Adopting default node template %q", name)
+
+	d.SetId(name)
+	if err := d.Set(FieldNodeTemplateName, name); err != nil {
+		return diag.FromErr(fmt.Errorf("setting name: %w", err))
+	}
+	if err := d.Set(FieldNodeTemplateIsDefault, true); err != nil {
+		return diag.FromErr(fmt.Errorf("setting is default: %w", err))
+	}
+	if err := setDefaultNodeTemplateOriginalSettings(d, *nodeTemplate); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return updateNodeTemplate(ctx, d, meta, true)
+}
+
+func resourceDefaultNodeTemplateDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ProviderConfig).api
+	clusterID := d.Get(FieldClusterID).(string)
+
+	originalSettings := d.Get(FieldDefaultNodeTemplateOriginalSettings).(string)
+	if originalSettings == "" {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Skipping restore of \"%s\" node template", d.Id()),
+				Detail:   "Settings of the default node template before it was adopted are unknown, the node template is left as is.",
+			},
+		}
+	}
+
+	var req sdk.NodeTemplatesAPIUpdateNodeTemplateJSONRequestBody
+	if err := json.Unmarshal([]byte(originalSettings), &req); err != nil {
+		return diag.FromErr(fmt.Errorf("parsing original settings: %w", err))
+	}
+
+	log.Printf("[INFO] Restoring original settings of default node template %q", d.Id())
+
+	resp, err := client.NodeTemplatesAPIUpdateNodeTemplateWithResponse(ctx, clusterID, d.Id(), req)
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return diag.FromErr(checkErr)
+	}
+
+	return nil
+}
+
+// setDefaultNodeTemplateOriginalSettings records settings of the default node template before it is adopted, so that
+// they can be restored on destroy.
+func setDefaultNodeTemplateOriginalSettings(d *schema.ResourceData, nodeTemplate sdk.NodetemplatesV1NodeTemplate) error {
+	b, err := json.Marshal(nodeTemplateToUpdateRequest(nodeTemplate))
+	if err != nil {
+		return fmt.Errorf("marshaling original settings: %w", err)
+	}
+	if err := d.Set(FieldDefaultNodeTemplateOriginalSettings, string(b)); err != nil {
+		return fmt.Errorf("setting original settings: %w", err)
+	}
+	return nil
+}
+
+// nodeTemplateToUpdateRequest returns the update request which sets all settings of the node template to the given values.
+func nodeTemplateToUpdateRequest(t sdk.NodetemplatesV1NodeTemplate) sdk.NodeTemplatesAPIUpdateNodeTemplateJSONRequestBody {
+	labels := map[string]string{}
+	if t.CustomLabels != nil && t.CustomLabels.AdditionalProperties != nil {
+		labels = t.CustomLabels.AdditionalProperties
+	}
+	taints := lo.Map(lo.FromPtr(t.CustomTaints), func(taint sdk.NodetemplatesV1Taint, _ int) sdk.NodetemplatesV1TaintWithOptionalEffect {
+		return sdk.NodetemplatesV1TaintWithOptionalEffect{
+			Key:    lo.FromPtr(taint.Key),
+			Value:  taint.Value,
+			Effect: taint.Effect,
+		}
+	})
+
+	return sdk.NodeTemplatesAPIUpdateNodeTemplateJSONRequestBody{
+		ConfigurationId:                          t.ConfigurationId,
+		Constraints:                              t.Constraints,
+		CustomInstancesEnabled:                   t.CustomInstancesEnabled,
+		CustomInstancesWithExtendedMemoryEnabled: t.CustomInstancesWithExtendedMemoryEnabled,
+		CustomLabel:                              t.CustomLabel,
+		CustomLabels:                             &sdk.NodetemplatesV1UpdateNodeTemplate_CustomLabels{AdditionalProperties: labels},
+		CustomTaints:                             &taints,
+		IsDefault:                                lo.ToPtr(true),
+		IsEnabled:                                t.IsEnabled,
+		RebalancingConfig:                        t.RebalancingConfig,
+		ShouldTaint:                              t.ShouldTaint,
+	}
+}
+
+func getDefaultNodeTemplate(ctx context.Context, client *sdk.ClientWithResponses, clusterID string) (*sdk.NodetemplatesV1NodeTemplate, error) {
+	resp, err := client.NodeTemplatesAPIListNodeTemplatesWithResponse(ctx, clusterID, &sdk.NodeTemplatesAPIListNodeTemplatesParams{IncludeDefault: lo.ToPtr(true)})
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return nil, checkErr
+	}
+
+	t, ok := lo.Find(lo.FromPtr(resp.JSON200.Items), func(t sdk.NodetemplatesV1NodeTemplateListItem) bool {
+		return t.Template != nil && lo.FromPtr(t.Template.IsDefault)
+	})
+	if !ok {
+		return nil, nil
+	}
+
+	return t.Template, nil
+}
+
+func defaultNodeTemplateStateImporter(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	clusterID := d.Id()
+	if err := d.Set(FieldClusterID, clusterID); err != nil {
+		return nil, fmt.Errorf("setting cluster id: %w", err)
+	}
+
+	nodeTemplate, err := getDefaultNodeTemplate(ctx, meta.(*ProviderConfig).api, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	if nodeTemplate == nil {
+		return nil, fmt.Errorf("failed to find default node template for cluster: %v", clusterID)
+	}
+
+	if err := setDefaultNodeTemplateOriginalSettings(d, *nodeTemplate); err != nil {
+		return nil, err
+	}
+
+	d.SetId(lo.FromPtr(nodeTemplate.Name))
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,180 @@
+package castai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+func TestDefaultNodeTemplateResourceCreate(t *testing.T) {
+	r := require.New(t)
+	mockctrl := gomock.NewController(t)
+	mockClient := mock_sdk.NewMockClientInterface(mockctrl)
+
+	ctx := context.Background()
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterId := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	listResponse := func() *http.Response {
+		body := io.NopCloser(bytes.NewReader([]byte(`
+		{
+		  "items": [
+			{
+			  "template": {
+				"name": "gpu",
+				"isEnabled": true,
+				"isDefault": false
+			  }
+			},
+			{
+			  "template": {
+				"configurationId": "7dc4f922-29c9-4377-889c-0c8c5fb8d497",
+				"name": "default-by-castai",
+				"isEnabled": true,
+				"isDefault": true,
+				"shouldTaint": false,
+				"customLabels": {"team": "platform"},
+				"constraints": {
+				  "spot": true,
+				  "onDemand": true
+				}
+			  }
+			}
+		  ]
+		}
+	`)))
+		return &http.Response{StatusCode: 200, Body: body, Header: map[string][]string{"Content-Type": {"json"}}}
+	}
+
+	mockClient.EXPECT().
+		NodeTemplatesAPIListNodeTemplates(gomock.Any(), clusterId, &sdk.NodeTemplatesAPIListNodeTemplatesParams{IncludeDefault: lo.ToPtr(true)}).
+		DoAndReturn(func(_ context.Context, _ string, _ *sdk.NodeTemplatesAPIListNodeTemplatesParams) (*http.Response, error) {
+			return listResponse(), nil
+		}).Times(2)
+	mockClient.EXPECT().
+		NodeTemplatesAPIUpdateNodeTemplate(gomock.Any(), clusterId, "default-by-castai", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ string, req sdk.NodeTemplatesAPIUpdateNodeTemplateJSONRequestBody) (*http.Response, error) {
+			r.True(lo.FromPtr(req.IsDefault))
+			r.True(lo.FromPtr(req.Constraints.Spot))
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{}`)))}, nil
+		})
+
+	resource := resourceDefaultNodeTemplate()
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterId:               cty.StringVal(clusterId),
+		FieldNodeTemplateShouldTaint: cty.False,
+		FieldNodeTemplateConstraints: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			FieldNodeTemplateSpot:     cty.True,
+			FieldNodeTemplateOnDemand: cty.True,
+		})}),
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+
+	data := resource.Data(state)
+	result := resource.CreateContext(ctx, data, provider)
+	r.Nil(result)
+	r.Equal("default-by-castai", data.Id())
+	r.Equal("default-by-castai", data.Get(FieldNodeTemplateName))
+	r.True(data.Get(FieldNodeTemplateIsDefault).(bool))
+
+	var originalSettings sdk.NodeTemplatesAPIUpdateNodeTemplateJSONRequestBody
+	r.NoError(json.Unmarshal([]byte(data.Get(FieldDefaultNodeTemplateOriginalSettings).(string)), &originalSettings))
+	r.Equal("7dc4f922-29c9-4377-889c-0c8c5fb8d497", lo.FromPtr(originalSettings.ConfigurationId))
+	r.Equal(map[string]string{"team": "platform"}, originalSettings.CustomLabels.AdditionalProperties)
+	r.NotNil(originalSettings.CustomTaints)
+	r.True(lo.FromPtr(originalSettings.IsDefault))
+	r.False(lo.FromPtr(originalSettings.ShouldTaint))
+	r.True(lo.FromPtr(originalSettings.Constraints.Spot))
+}
+
+func TestDefaultNodeTemplateResourceDelete(t *testing.T) {
+	r := require.New(t)
+	mockctrl := gomock.NewController(t)
+	mockClient := mock_sdk.NewMockClientInterface(mockctrl)
+
+	ctx := context.Background()
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterId := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	mockClient.EXPECT().
+		NodeTemplatesAPIUpdateNodeTemplate(gomock.Any(), clusterId, "default-by-castai", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ string, req sdk.NodeTemplatesAPIUpdateNodeTemplateJSONRequestBody) (*http.Response, error) {
+			r.True(lo.FromPtr(req.IsDefault))
+			r.True(lo.FromPtr(req.IsEnabled))
+			r.False(lo.FromPtr(req.ShouldTaint))
+			r.Equal("7dc4f922-29c9-4377-889c-0c8c5fb8d497", lo.FromPtr(req.ConfigurationId))
+			r.Equal(map[string]string{"team": "platform"}, req.CustomLabels.AdditionalProperties)
+			r.Empty(lo.FromPtr(req.CustomTaints))
+			r.True(lo.FromPtr(req.Constraints.Spot))
+			r.True(lo.FromPtr(req.Constraints.OnDemand))
+			r.Nil(req.Constraints.MinCpu)
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{}`)))}, nil
+		})
+
+	resource := resourceDefaultNodeTemplate()
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterId:        cty.StringVal(clusterId),
+		FieldNodeTemplateName: cty.StringVal("default-by-castai"),
+		FieldDefaultNodeTemplateOriginalSettings: cty.StringVal(`{
+			"configurationId": "7dc4f922-29c9-4377-889c-0c8c5fb8d497",
+			"constraints": {"spot": true, "onDemand": true},
+			"customLabels": {"team": "platform"},
+			"customTaints": [],
+			"isDefault": true,
+			"isEnabled": true,
+			"shouldTaint": false
+		}`),
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+	state.ID = "default-by-castai"
+
+	data := resource.Data(state)
+	result := resource.DeleteContext(ctx, data, provider)
+	r.Nil(result)
+}
+
+func TestDefaultNodeTemplateResourceDelete_originalSettingsUnknown(t *testing.T) {
+	r := require.New(t)
+	mockctrl := gomock.NewController(t)
+	mockClient := mock_sdk.NewMockClientInterface(mockctrl)
+
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	resource := resourceDefaultNodeTemplate()
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterId:        cty.StringVal("b6bfc074-a267-400f-b8f1-db0850c369b1"),
+		FieldNodeTemplateName: cty.StringVal("default-by-castai"),
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+	state.ID = "default-by-castai"
+
+	data := resource.Data(state)
+	result := resource.DeleteContext(context.Background(), data, provider)
+	r.Len(result, 1)
+	r.Equal(diag.Warning, result[0].Severity)
+}
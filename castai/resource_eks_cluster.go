@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/castai/terraform-provider-castai/castai/sdk"
+	castval "github.com/castai/terraform-provider-castai/castai/validation"
 )
 
 const (
@@ -48,7 +49,7 @@ func resourceEKSCluster() *schema.Resource {
 				Type:             schema.TypeString,
 				Required:         true,
 				ForceNew:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+				ValidateDiagFunc: castval.All(validation.ToDiagFunc(validation.StringIsNotWhiteSpace), castval.Warning(castval.ValidAWSAccountID())),
 				Description:      "ID of AWS account",
 			},
 			FieldEKSClusterRegion: {
@@ -59,8 +60,9 @@ func resourceEKSCluster() *schema.Resource {
 				Description:      "AWS region where the cluster is placed",
 			},
 			FieldEKSClusterAssumeRoleArn: {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: castval.Warning(castval.ValidARN("iam", "role")),
 				Description: "AWS IAM role ARN that will be assumed by CAST AI user. " +
 					"This role should allow `sts:AssumeRole` action for CAST AI user that can be retrieved using `castai_eks_user_arn` data source",
 			},
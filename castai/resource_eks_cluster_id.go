@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/castai/terraform-provider-castai/castai/sdk"
+	castval "github.com/castai/terraform-provider-castai/castai/validation"
 )
 
 func resourceEKSClusterID() *schema.Resource {
@@ -21,7 +22,7 @@ func resourceEKSClusterID() *schema.Resource {
 				Type:             schema.TypeString,
 				Required:         true,
 				ForceNew:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+				ValidateDiagFunc: castval.All(validation.ToDiagFunc(validation.StringIsNotWhiteSpace), castval.Warning(castval.ValidAWSAccountID())),
 			},
 			"region": {
 				Type:             schema.TypeString,
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/castai/terraform-provider-castai/castai/sdk"
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -38,6 +39,7 @@ func resourceEvictionConfig() *schema.Resource {
 		CreateContext: resourceEvictionConfigCreate,
 		UpdateContext: resourceEvictionConfigUpdate,
 		DeleteContext: resourceEvictionConfigDelete,
+		CustomizeDiff: evictionConfigDiff,
 		Description:   "CAST AI eviction config resource to manage evictor properties ",
 
 		Timeouts: &schema.ResourceTimeout{
@@ -62,36 +64,7 @@ func resourceEvictionConfig() *schema.Resource {
 							Description: "pod selector",
 							Optional:    true,
 							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									FieldPodSelectorNamespace: {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-									FieldPodSelectorKind: {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-									FieldMatchLabels: {
-										Type:     schema.TypeMap,
-										Optional: true,
-										Elem:     &schema.Schema{Type: schema.TypeString},
-									},
-									FieldMatchExpressions: {
-										Type:     schema.TypeList,
-										Optional: true,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												FieldMatchExpressionKey: {Type: schema.TypeString, Required: true},
-												FieldMatchExpressionOp:  {Type: schema.TypeString, Required: true},
-												FieldMatchExpressionVal: {
-													Type:     schema.TypeList,
-													Elem:     &schema.Schema{Type: schema.TypeString},
-													Optional: true,
-												},
-											},
-										},
-									},
-								},
+								Schema: podSelectorFields(),
 							},
 						},
 						FieldNodeSelector: {
@@ -99,28 +72,7 @@ func resourceEvictionConfig() *schema.Resource {
 							Description: "node selector",
 							Optional:    true,
 							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									FieldMatchLabels: {
-										Type:     schema.TypeMap,
-										Optional: true,
-										Elem:     &schema.Schema{Type: schema.TypeString},
-									},
-									FieldMatchExpressions: {
-										Type:     schema.TypeList,
-										Optional: true,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												FieldMatchExpressionKey: {Type: schema.TypeString, Required: true},
-												FieldMatchExpressionOp:  {Type: schema.TypeString, Required: true},
-												FieldMatchExpressionVal: {
-													Type:     schema.TypeList,
-													Elem:     &schema.Schema{Type: schema.TypeString},
-													Optional: true,
-												},
-											},
-										},
-									},
-								},
+								Schema: labelSelectorFields(labelSelectorOperators),
 							},
 						},
 						FieldEvictionOptionDisabled: {
@@ -142,6 +94,37 @@ func resourceEvictionConfig() *schema.Resource {
 	}
 }
 
+func podSelectorFields() map[string]*schema.Schema {
+	fields := labelSelectorFields(labelSelectorOperators)
+	fields[FieldPodSelectorNamespace] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	fields[FieldPodSelectorKind] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	return fields
+}
+
+func evictionConfigDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	var result *multierror.Error
+
+	configs, _ := d.Get(FieldEvictorAdvancedConfig).([]any)
+	for i := range configs {
+		for _, selector := range []string{FieldPodSelector, FieldNodeSelector} {
+			selectors, _ := d.Get(fmt.Sprintf("%s.%d.%s", FieldEvictorAdvancedConfig, i, selector)).([]any)
+			for j := range selectors {
+				if err := validateLabelSelector(d, fmt.Sprintf("%s.%d.%s.%d", FieldEvictorAdvancedConfig, i, selector, j), labelSelectorOperators); err != nil {
+					result = multierror.Append(result, err)
+				}
+			}
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
 func resourceEvictionConfigRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := readAdvancedEvictorConfig(ctx, data, meta)
 	if err != nil {
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -408,3 +409,95 @@ func TestEvictionConfig_DeleteContext(t *testing.T) {
 	r.False(isOK)
 	r.Equal([]interface{}{}, eac)
 }
+
+func TestEvictionConfig_CustomizeDiff(t *testing.T) {
+	tt := map[string]struct {
+		expression cty.Value
+		errMsg     string
+	}{
+		"in with values": {
+			expression: cty.ObjectVal(map[string]cty.Value{
+				"key":      cty.StringVal("app.kubernetes.io/name"),
+				"operator": cty.StringVal("In"),
+				"values":   cty.ListVal([]cty.Value{cty.StringVal("web")}),
+			}),
+		},
+		"in without values": {
+			expression: cty.ObjectVal(map[string]cty.Value{
+				"key":      cty.StringVal("app.kubernetes.io/name"),
+				"operator": cty.StringVal("In"),
+				"values":   cty.ListValEmpty(cty.String),
+			}),
+		},
+		"gt is not checked": {
+			expression: cty.ObjectVal(map[string]cty.Value{
+				"key":      cty.StringVal("app.kubernetes.io/version"),
+				"operator": cty.StringVal("Gt"),
+				"values":   cty.ListVal([]cty.Value{cty.StringVal("latest")}),
+			}),
+		},
+		"exists with values": {
+			expression: cty.ObjectVal(map[string]cty.Value{
+				"key":      cty.StringVal("app.kubernetes.io/name"),
+				"operator": cty.StringVal("Exists"),
+				"values":   cty.ListVal([]cty.Value{cty.StringVal("web")}),
+			}),
+			errMsg: "evictor_advanced_config.0.pod_selector.0.match_expressions.0: operator Exists does not accept values, got 1",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+
+			resource := resourceEvictionConfig()
+			val := cty.ObjectVal(map[string]cty.Value{
+				FieldClusterId: cty.StringVal("b6bfc074-a267-400f-b8f1-db0850c369b1"),
+				FieldEvictorAdvancedConfig: cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						FieldPodSelector: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+							FieldMatchExpressions: cty.ListVal([]cty.Value{tc.expression}),
+						})}),
+						FieldEvictionOptionAggressive: cty.BoolVal(true),
+					}),
+				}),
+			})
+			state := &terraform.InstanceState{RawConfig: val}
+			config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+			_, err := resource.Diff(context.Background(), state, config, &ProviderConfig{})
+			if tc.errMsg == "" {
+				r.NoError(err)
+				return
+			}
+			r.Error(err)
+			r.Contains(err.Error(), tc.errMsg)
+		})
+	}
+}
+
+func TestEvictionConfig_Validate(t *testing.T) {
+	r := require.New(t)
+
+	diags := resourceEvictionConfig().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		FieldEvictorAdvancedConfig: []interface{}{map[string]interface{}{
+			FieldNodeSelector: []interface{}{map[string]interface{}{
+				FieldMatchLabels: map[string]interface{}{"Node Pool": "spot"},
+				FieldMatchExpressions: []interface{}{map[string]interface{}{
+					"key":      "node.kubernetes.io/instance-type",
+					"operator": "Gt",
+					"values":   []interface{}{"4"},
+				}},
+			}},
+		}},
+	}))
+	r.False(diags.HasError())
+	r.Len(diags, 2)
+	r.True(lo.EveryBy(diags, func(d diag.Diagnostic) bool { return d.Severity == diag.Warning }), diags)
+	r.True(lo.ContainsBy(diags, func(d diag.Diagnostic) bool {
+		return strings.Contains(d.Detail, `"Node Pool" is not a valid Kubernetes label key`)
+	}), diags)
+	r.True(lo.ContainsBy(diags, func(d diag.Diagnostic) bool {
+		return strings.Contains(d.Summary, `expected operator to be one of [In NotIn Exists DoesNotExist], got Gt`)
+	}), diags)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/castai/terraform-provider-castai/castai/sdk"
+	castval "github.com/castai/terraform-provider-castai/castai/validation"
 )
 
 const (
@@ -55,7 +56,7 @@ func resourceGKECluster() *schema.Resource {
 				Type:             schema.TypeString,
 				Required:         true,
 				ForceNew:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+				ValidateDiagFunc: castval.All(validation.ToDiagFunc(validation.StringIsNotWhiteSpace), castval.Warning(castval.ValidGCPProjectID())),
 				Description:      "GCP project id",
 			},
 			FieldGKEClusterLocation: {
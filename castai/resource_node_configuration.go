@@ -22,21 +22,29 @@ import (
 )
 
 const (
-	FieldNodeConfigurationName             = "name"
-	FieldNodeConfigurationDiskCpuRatio     = "disk_cpu_ratio"
-	FieldNodeConfigurationMinDiskSize      = "min_disk_size"
-	FieldNodeConfigurationSubnets          = "subnets"
-	FieldNodeConfigurationSSHPublicKey     = "ssh_public_key"
-	FieldNodeConfigurationImage            = "image"
-	FieldNodeConfigurationTags             = "tags"
-	FieldNodeConfigurationInitScript       = "init_script"
-	FieldNodeConfigurationContainerRuntime = "container_runtime"
-	FieldNodeConfigurationDockerConfig     = "docker_config"
-	FieldNodeConfigurationKubeletConfig    = "kubelet_config"
-	FieldNodeConfigurationAKS              = "aks"
-	FieldNodeConfigurationEKS              = "eks"
-	FieldNodeConfigurationKOPS             = "kops"
-	FieldNodeConfigurationGKE              = "gke"
+	FieldNodeConfigurationName                    = "name"
+	FieldNodeConfigurationDiskCpuRatio            = "disk_cpu_ratio"
+	FieldNodeConfigurationMinDiskSize             = "min_disk_size"
+	FieldNodeConfigurationSubnets                 = "subnets"
+	FieldNodeConfigurationSSHPublicKey            = "ssh_public_key"
+	FieldNodeConfigurationImage                   = "image"
+	FieldNodeConfigurationImageSelector           = "image_selector"
+	FieldNodeConfigurationResolvedImage           = "resolved_image"
+	FieldNodeConfigurationDefault                 = "default"
+	FieldNodeConfigurationFallbackConfigurationID = "fallback_configuration_id"
+	FieldNodeConfigurationTags                    = "tags"
+	FieldNodeConfigurationInitScript              = "init_script"
+	FieldNodeConfigurationInitScriptText          = "init_script_text"
+	FieldNodeConfigurationInitScriptPart          = "init_script_part"
+	FieldNodeConfigurationContainerRuntime        = "container_runtime"
+	FieldNodeConfigurationDockerConfig            = "docker_config"
+	FieldNodeConfigurationKubeletConfig           = "kubelet_config"
+	FieldNodeConfigurationDocker                  = "docker"
+	FieldNodeConfigurationKubelet                 = "kubelet"
+	FieldNodeConfigurationAKS                     = "aks"
+	FieldNodeConfigurationEKS                     = "eks"
+	FieldNodeConfigurationKOPS                    = "kops"
+	FieldNodeConfigurationGKE                     = "gke"
 )
 
 func resourceNodeConfiguration() *schema.Resource {
@@ -89,21 +97,42 @@ func resourceNodeConfiguration() *schema.Resource {
 				Type:     schema.TypeList,
 				Required: true,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:             schema.TypeString,
+					ValidateDiagFunc: castval.Warning(castval.ValidSubnetID()),
 				},
 				Description: "Subnet ids to be used for provisioned nodes",
 			},
 			FieldNodeConfigurationSSHPublicKey: {
 				Type:             schema.TypeString,
 				Optional:         true,
-				Description:      "SSH public key to be used for provisioned nodes",
-				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsBase64),
+				Description:      "SSH public key to be used for provisioned nodes. Value should be base64 encoded",
+				ValidateDiagFunc: castval.All(validation.ToDiagFunc(validation.StringIsBase64), castval.Warning(castval.ValidBase64SSHPublicKey())),
 			},
 			FieldNodeConfigurationImage: {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Description:      "Image to be used while provisioning the node. If nothing is provided will be resolved to latest available image based on Kubernetes version if possible ",
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+				ConflictsWith:    []string{FieldNodeConfigurationImageSelector},
+			},
+			FieldNodeConfigurationImageSelector: imageSelectorSchema(),
+			FieldNodeConfigurationDefault: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				Description: "Whether the node configuration is the default one of the cluster. Setting it to true promotes the configuration to default. " +
+					"Setting it to false requires `fallback_configuration_id` to be set, or another configuration to be marked as default beforehand",
+			},
+			FieldNodeConfigurationFallbackConfigurationID: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+				Description:      "Id of the node configuration promoted to default when this configuration stops being the default one or is deleted",
+			},
+			FieldNodeConfigurationResolvedImage: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Image resolved from `image_selector`",
 			},
 			FieldNodeConfigurationTags: {
 				Type:     schema.TypeMap,
@@ -118,7 +147,17 @@ func resourceNodeConfiguration() *schema.Resource {
 				Optional:         true,
 				Description:      "Init script to be run on your instance at launch. Should not contain any sensitive data. Value should be base64 encoded",
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsBase64),
+				ConflictsWith:    []string{FieldNodeConfigurationInitScriptText, FieldNodeConfigurationInitScriptPart},
 			},
+			FieldNodeConfigurationInitScriptText: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Plain text alternative to `init_script`, base64 encoded by the provider. Should not contain any sensitive data. " +
+					"Supports `${cluster_id}`, `${configuration_name}` and `${region}` placeholders, which have to be escaped as `$${...}` in Terraform strings",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+				ConflictsWith:    []string{FieldNodeConfigurationInitScript, FieldNodeConfigurationInitScriptPart},
+			},
+			FieldNodeConfigurationInitScriptPart: initScriptPartSchema(),
 			FieldNodeConfigurationContainerRuntime: {
 				Type:             schema.TypeString,
 				Optional:         true,
@@ -133,15 +172,21 @@ func resourceNodeConfiguration() *schema.Resource {
 				Optional: true,
 				Description: "Optional docker daemon configuration properties in JSON format. Provide only properties that you want to override. Applicable for EKS only. " +
 					"[Available values](https://docs.docker.com/engine/reference/commandline/dockerd/#daemon-configuration-file)",
-				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+				ValidateDiagFunc: validateDockerConfigJSON,
+				DiffSuppressFunc: suppressEquivalentJSONDiffs,
+				ConflictsWith:    []string{FieldNodeConfigurationDocker},
 			},
 			FieldNodeConfigurationKubeletConfig: {
 				Type:     schema.TypeString,
 				Optional: true,
 				Description: "Optional kubelet configuration properties in JSON format. Provide only properties that you want to override. Applicable for EKS only. " +
 					"[Available values](https://kubernetes.io/docs/reference/config-api/kubelet-config.v1beta1/)",
-				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+				ValidateDiagFunc: validateKubeletConfigJSON,
+				DiffSuppressFunc: suppressEquivalentJSONDiffs,
+				ConflictsWith:    []string{FieldNodeConfigurationKubelet},
 			},
+			FieldNodeConfigurationDocker:  dockerConfigSchema(),
+			FieldNodeConfigurationKubelet: kubeletConfigSchema(),
 			FieldNodeConfigurationEKS: {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -153,7 +198,8 @@ func resourceNodeConfiguration() *schema.Resource {
 							Required: true,
 							MinItems: 1,
 							Elem: &schema.Schema{
-								Type: schema.TypeString,
+								Type:             schema.TypeString,
+								ValidateDiagFunc: castval.Warning(castval.ValidAWSSecurityGroupID()),
 							},
 							Description: "Cluster's security groups configuration for CAST provisioned nodes",
 						},
@@ -167,7 +213,7 @@ func resourceNodeConfiguration() *schema.Resource {
 							Type:             schema.TypeString,
 							Required:         true,
 							Description:      "Cluster's instance profile ARN used for CAST provisioned nodes",
-							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+							ValidateDiagFunc: castval.All(validation.ToDiagFunc(validation.StringIsNotWhiteSpace), castval.Warning(castval.ValidARN("iam", "instance-profile"))),
 						},
 						"key_pair_id": {
 							Type:             schema.TypeString,
@@ -210,8 +256,10 @@ func resourceNodeConfiguration() *schema.Resource {
 							Type:             schema.TypeString,
 							Optional:         true,
 							Description:      "AWS KMS key ARN for encrypting EBS volume attached to the node",
-							ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`arn:aws:kms:.*`), "Must be a valid KMS key ARN")),
+							ValidateDiagFunc: castval.All(validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`arn:aws[-a-z]*:kms:.*`), "Must be a valid KMS key ARN")), castval.Warning(castval.ValidKMSKeyARN())),
 						},
+						FieldEKSBottlerocket: eksBottlerocketSchema(),
+						FieldEKSAL2023:       eksAL2023Schema(),
 					},
 				},
 			},
@@ -268,7 +316,8 @@ func resourceNodeConfiguration() *schema.Resource {
 						"network_tags": {
 							Type: schema.TypeList,
 							Elem: &schema.Schema{
-								Type: schema.TypeString,
+								Type:             schema.TypeString,
+								ValidateDiagFunc: castval.Warning(castval.ValidGCPNetworkTag()),
 							},
 							MaxItems:    64,
 							Optional:    true,
@@ -284,16 +333,31 @@ func resourceNodeConfiguration() *schema.Resource {
 				},
 			},
 		},
-		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, i interface{}) error {
-			return nil
-		},
+		CustomizeDiff: nodeConfigurationDiff,
 	}
 }
 
+func nodeConfigurationDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if err := nodeConfigurationRuntimeDiff(diff); err != nil {
+		return err
+	}
+	if err := nodeConfigurationInitScriptDiff(diff); err != nil {
+		return err
+	}
+	if err := nodeConfigurationEKSUserDataDiff(diff); err != nil {
+		return err
+	}
+	if err := nodeConfigurationCloudDiff(ctx, diff, meta); err != nil {
+		return err
+	}
+	return nodeConfigurationImageDiff(ctx, diff, meta)
+}
+
 func resourceNodeConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ProviderConfig).api
 
 	clusterID := d.Get(FieldClusterID).(string)
+	getCluster := clusterGetter(ctx, client, clusterID)
 	req := sdk.NodeConfigurationAPICreateConfigurationJSONRequestBody{
 		Name:         d.Get(FieldNodeConfigurationName).(string),
 		DiskCpuRatio: toPtr(int32(d.Get(FieldNodeConfigurationDiskCpuRatio).(int))),
@@ -303,31 +367,43 @@ func resourceNodeConfigurationCreate(ctx context.Context, d *schema.ResourceData
 	if v, ok := d.GetOk(FieldNodeConfigurationSubnets); ok {
 		req.Subnets = toPtr(toStringList(v.([]interface{})))
 	}
-	if v, ok := d.GetOk(FieldNodeConfigurationImage); ok {
-		req.Image = toPtr(v.(string))
+	image, err := imageFromResourceData(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("resolving image: %w", err))
+	}
+	if image != "" {
+		req.Image = toPtr(image)
 	}
 	if v, ok := d.GetOk(FieldNodeConfigurationSSHPublicKey); ok {
 		req.SshPublicKey = toPtr(v.(string))
 	}
-	if v, ok := d.GetOk(FieldNodeConfigurationInitScript); ok {
-		req.InitScript = toPtr(v.(string))
+	diags := nodeConfigurationEKSOnlyFieldsWarnings(d, getCluster)
+	if diags.HasError() {
+		return diags
+	}
+	initScript, err := initScriptFromResourceData(d, getCluster)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("composing init script: %w", err))
+	}
+	if initScript != "" {
+		req.InitScript = toPtr(initScript)
 	}
 	if v, ok := d.GetOk(FieldNodeConfigurationContainerRuntime); ok {
 		req.ContainerRuntime = toPtr(sdk.NodeconfigV1ContainerRuntime(v.(string)))
 	}
-	if v, ok := d.GetOk(FieldNodeConfigurationDockerConfig); ok {
-		m, err := stringToMap(v.(string))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		req.DockerConfig = toPtr(m)
+	dockerCfg, err := dockerConfigFromResourceData(d)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("reading docker config: %w", err))
 	}
-	if v, ok := d.GetOk(FieldNodeConfigurationKubeletConfig); ok {
-		m, err := stringToMap(v.(string))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		req.KubeletConfig = toPtr(m)
+	if dockerCfg != nil {
+		req.DockerConfig = toPtr(dockerCfg)
+	}
+	kubeletCfg, err := kubeletConfigFromResourceData(d)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("reading kubelet config: %w", err))
+	}
+	if kubeletCfg != nil {
+		req.KubeletConfig = toPtr(kubeletCfg)
 	}
 	if v := d.Get(FieldNodeConfigurationTags).(map[string]interface{}); len(v) > 0 {
 		req.Tags = &sdk.NodeconfigV1NewNodeConfiguration_Tags{
@@ -356,7 +432,13 @@ func resourceNodeConfigurationCreate(ctx context.Context, d *schema.ResourceData
 
 	d.SetId(*resp.JSON200.Id)
 
-	return resourceNodeConfigurationRead(ctx, d, meta)
+	if d.Get(FieldNodeConfigurationDefault).(bool) {
+		if err := setDefaultNodeConfiguration(ctx, client, clusterID, d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return append(diags, resourceNodeConfigurationRead(ctx, d, meta)...)
 }
 
 func resourceNodeConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -387,16 +469,37 @@ func resourceNodeConfigurationRead(ctx context.Context, d *schema.ResourceData,
 	if err := d.Set(FieldNodeConfigurationMinDiskSize, nodeConfig.MinDiskSize); err != nil {
 		return diag.FromErr(fmt.Errorf("setting min disk size: %w", err))
 	}
+	if err := d.Set(FieldNodeConfigurationDefault, lo.FromPtr(nodeConfig.Default)); err != nil {
+		return diag.FromErr(fmt.Errorf("setting default: %w", err))
+	}
 	if err := d.Set(FieldNodeConfigurationSubnets, nodeConfig.Subnets); err != nil {
 		return diag.FromErr(fmt.Errorf("setting subnets: %w", err))
 	}
 	if err := d.Set(FieldNodeConfigurationSSHPublicKey, nodeConfig.SshPublicKey); err != nil {
 		return diag.FromErr(fmt.Errorf("setting ssh public key: %w", err))
 	}
-	if err := d.Set(FieldNodeConfigurationImage, nodeConfig.Image); err != nil {
+	if _, ok := d.GetOk(FieldNodeConfigurationImageSelector); ok {
+		if err := d.Set(FieldNodeConfigurationResolvedImage, nodeConfig.Image); err != nil {
+			return diag.FromErr(fmt.Errorf("setting resolved image: %w", err))
+		}
+	} else if err := d.Set(FieldNodeConfigurationImage, nodeConfig.Image); err != nil {
 		return diag.FromErr(fmt.Errorf("setting image: %w", err))
 	}
-	if err := d.Set(FieldNodeConfigurationInitScript, nodeConfig.InitScript); err != nil {
+	initScriptDrifted := false
+	if usesInitScriptTemplate(d) {
+		// Plain text init script is kept as configured unless the rendered one has drifted.
+		initScript, err := initScriptFromResourceData(d, clusterGetter(ctx, client, d.Get(FieldClusterID).(string)))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("composing init script: %w", err))
+		}
+		if actual := lo.FromPtr(nodeConfig.InitScript); actual != initScript {
+			log.Printf("[WARN] Init script of node configuration (%s) has drifted", d.Id())
+			initScriptDrifted = true
+			if err := setDriftedInitScript(d, actual); err != nil {
+				return diag.FromErr(fmt.Errorf("setting init script: %w", err))
+			}
+		}
+	} else if err := d.Set(FieldNodeConfigurationInitScript, nodeConfig.InitScript); err != nil {
 		return diag.FromErr(fmt.Errorf("setting init script: %w", err))
 	}
 	if err := d.Set(FieldNodeConfigurationContainerRuntime, nodeConfig.ContainerRuntime); err != nil {
@@ -406,26 +509,58 @@ func resourceNodeConfigurationRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(fmt.Errorf("setting tags: %w", err))
 	}
 
+	// Docker and kubelet configuration is kept in the form used in the configuration, JSON being the default one.
+	// Properties without typed attributes are kept in the JSON attribute, so they show up as drift of the typed block.
 	if cfg := nodeConfig.DockerConfig; cfg != nil {
-		b, err := json.Marshal(nodeConfig.DockerConfig)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		if err := d.Set(FieldNodeConfigurationDockerConfig, string(b)); err != nil {
-			return diag.FromErr(fmt.Errorf("setting docker config: %w", err))
+		if _, ok := d.GetOk(FieldNodeConfigurationDocker); ok {
+			typed := &dockerConfig{}
+			if err := fromRuntimeConfigMap(*cfg, typed); err != nil {
+				return diag.FromErr(fmt.Errorf("parsing docker config: %w", err))
+			}
+			if err := d.Set(FieldNodeConfigurationDocker, flattenDockerConfig(typed)); err != nil {
+				return diag.FromErr(fmt.Errorf("setting docker: %w", err))
+			}
+			if err := setUntypedRuntimeConfig(d, FieldNodeConfigurationDockerConfig, untypedRuntimeConfig(*cfg, typed)); err != nil {
+				return diag.FromErr(fmt.Errorf("setting docker config: %w", err))
+			}
+		} else {
+			b, err := json.Marshal(cfg)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if err := d.Set(FieldNodeConfigurationDockerConfig, string(b)); err != nil {
+				return diag.FromErr(fmt.Errorf("setting docker config: %w", err))
+			}
 		}
 	}
 	if cfg := nodeConfig.KubeletConfig; cfg != nil {
-		b, err := json.Marshal(nodeConfig.KubeletConfig)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		if err := d.Set(FieldNodeConfigurationKubeletConfig, string(b)); err != nil {
-			return diag.FromErr(fmt.Errorf("setting kubelet config: %w", err))
+		if _, ok := d.GetOk(FieldNodeConfigurationKubelet); ok {
+			typed := &kubeletConfig{}
+			if err := fromRuntimeConfigMap(*cfg, typed); err != nil {
+				return diag.FromErr(fmt.Errorf("parsing kubelet config: %w", err))
+			}
+			if err := d.Set(FieldNodeConfigurationKubelet, flattenKubeletConfig(typed)); err != nil {
+				return diag.FromErr(fmt.Errorf("setting kubelet: %w", err))
+			}
+			if err := setUntypedRuntimeConfig(d, FieldNodeConfigurationKubeletConfig, untypedRuntimeConfig(*cfg, typed)); err != nil {
+				return diag.FromErr(fmt.Errorf("setting kubelet config: %w", err))
+			}
+		} else {
+			b, err := json.Marshal(cfg)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if err := d.Set(FieldNodeConfigurationKubeletConfig, string(b)); err != nil {
+				return diag.FromErr(fmt.Errorf("setting kubelet config: %w", err))
+			}
 		}
 	}
 
-	if err := d.Set(FieldNodeConfigurationEKS, flattenEKSConfig(nodeConfig.Eks)); err != nil {
+	eks := flattenEKSConfig(nodeConfig.Eks)
+	if !initScriptDrifted {
+		keepEKSUserDataSettings(d, eks)
+	}
+	if err := d.Set(FieldNodeConfigurationEKS, eks); err != nil {
 		return diag.Errorf("error setting eks config: %v", err)
 	}
 	if err := d.Set(FieldNodeConfigurationKOPS, flattenKOPSConfig(nodeConfig.Kops)); err != nil {
@@ -448,10 +583,16 @@ func resourceNodeConfigurationUpdate(ctx context.Context, d *schema.ResourceData
 		FieldNodeConfigurationSubnets,
 		FieldNodeConfigurationSSHPublicKey,
 		FieldNodeConfigurationImage,
+		FieldNodeConfigurationImageSelector,
+		FieldNodeConfigurationResolvedImage,
 		FieldNodeConfigurationInitScript,
+		FieldNodeConfigurationInitScriptText,
+		FieldNodeConfigurationInitScriptPart,
 		FieldNodeConfigurationContainerRuntime,
 		FieldNodeConfigurationDockerConfig,
 		FieldNodeConfigurationKubeletConfig,
+		FieldNodeConfigurationDocker,
+		FieldNodeConfigurationKubelet,
 		FieldNodeConfigurationTags,
 		FieldNodeConfigurationAKS,
 		FieldNodeConfigurationEKS,
@@ -459,11 +600,12 @@ func resourceNodeConfigurationUpdate(ctx context.Context, d *schema.ResourceData
 		FieldNodeConfigurationGKE,
 	) {
 		log.Printf("[INFO] Nothing to update in node configuration")
-		return nil
+		return updateNodeConfigurationDefault(ctx, d, meta)
 	}
 
 	client := meta.(*ProviderConfig).api
 	clusterID := d.Get(FieldClusterID).(string)
+	getCluster := clusterGetter(ctx, client, clusterID)
 	req := sdk.NodeConfigurationAPIUpdateConfigurationJSONRequestBody{
 		DiskCpuRatio: toPtr(int32(d.Get(FieldNodeConfigurationDiskCpuRatio).(int))),
 		MinDiskSize:  toPtr(int32(d.Get(FieldNodeConfigurationMinDiskSize).(int))),
@@ -472,31 +614,43 @@ func resourceNodeConfigurationUpdate(ctx context.Context, d *schema.ResourceData
 	if v, ok := d.GetOk(FieldNodeConfigurationSubnets); ok {
 		req.Subnets = toPtr(toStringList(v.([]interface{})))
 	}
-	if v, ok := d.GetOk(FieldNodeConfigurationImage); ok {
-		req.Image = toPtr(v.(string))
+	image, err := imageFromResourceData(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("resolving image: %w", err))
+	}
+	if image != "" {
+		req.Image = toPtr(image)
 	}
 	if v, ok := d.GetOk(FieldNodeConfigurationSSHPublicKey); ok {
 		req.SshPublicKey = toPtr(v.(string))
 	}
-	if v, ok := d.GetOk(FieldNodeConfigurationInitScript); ok {
-		req.InitScript = toPtr(v.(string))
+	diags := nodeConfigurationEKSOnlyFieldsWarnings(d, getCluster)
+	if diags.HasError() {
+		return diags
+	}
+	initScript, err := initScriptFromResourceData(d, getCluster)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("composing init script: %w", err))
+	}
+	if initScript != "" {
+		req.InitScript = toPtr(initScript)
 	}
 	if v, ok := d.GetOk(FieldNodeConfigurationContainerRuntime); ok {
 		req.ContainerRuntime = toPtr(sdk.NodeconfigV1ContainerRuntime(v.(string)))
 	}
-	if v, ok := d.GetOk(FieldNodeConfigurationDockerConfig); ok {
-		m, err := stringToMap(v.(string))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		req.DockerConfig = toPtr(m)
+	dockerCfg, err := dockerConfigFromResourceData(d)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("reading docker config: %w", err))
 	}
-	if v, ok := d.GetOk(FieldNodeConfigurationKubeletConfig); ok {
-		m, err := stringToMap(v.(string))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		req.KubeletConfig = toPtr(m)
+	if dockerCfg != nil {
+		req.DockerConfig = toPtr(dockerCfg)
+	}
+	kubeletCfg, err := kubeletConfigFromResourceData(d)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("reading kubelet config: %w", err))
+	}
+	if kubeletCfg != nil {
+		req.KubeletConfig = toPtr(kubeletCfg)
 	}
 	if v := d.Get(FieldNodeConfigurationTags).(map[string]interface{}); len(v) > 0 {
 		req.Tags = &sdk.NodeconfigV1NodeConfigurationUpdate_Tags{
@@ -523,7 +677,11 @@ func resourceNodeConfigurationUpdate(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(checkErr)
 	}
 
-	return resourceNodeConfigurationRead(ctx, d, meta)
+	if defaultDiags := updateNodeConfigurationDefault(ctx, d, meta); defaultDiags.HasError() {
+		return defaultDiags
+	}
+
+	return append(diags, resourceNodeConfigurationRead(ctx, d, meta)...)
 }
 
 func resourceNodeConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -544,9 +702,15 @@ func resourceNodeConfigurationDelete(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	if *resp.JSON200.Default {
-		log.Printf("[WARN] Default node configuration (%s) can't be deleted, removing from state", d.Id())
-		return nil
+	if lo.FromPtr(resp.JSON200.Default) {
+		handedOver, err := handOverNodeConfigurationDefault(ctx, d, meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !handedOver {
+			log.Printf("[WARN] Default node configuration (%s) can't be deleted, removing from state", d.Id())
+			return nil
+		}
 	}
 
 	del, err := client.NodeConfigurationAPIDeleteConfigurationWithResponse(ctx, clusterID, d.Id())
@@ -706,7 +870,7 @@ func flattenAKSConfig(config *sdk.NodeconfigV1AKSConfig) []map[string]interface{
 		m["max_pods_per_node"] = *config.MaxPodsPerNode
 	}
 
-	if v := config.MaxPodsPerNode; v != nil {
+	if v := config.OsDiskType; v != nil {
 		m["os_disk_type"] = fromAKSDiskType(config.OsDiskType)
 	}
 
@@ -0,0 +1,37 @@
+package castai
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+)
+
+func TestFlattenAKSConfig(t *testing.T) {
+	tt := map[string]struct {
+		config   *sdk.NodeconfigV1AKSConfig
+		expected []map[string]interface{}
+	}{
+		"os disk type without max pods": {
+			config: &sdk.NodeconfigV1AKSConfig{
+				OsDiskType: lo.ToPtr(sdk.OSDISKTYPEPREMIUMSSD),
+			},
+			expected: []map[string]interface{}{{"os_disk_type": "premium-ssd"}},
+		},
+		"max pods without os disk type": {
+			config: &sdk.NodeconfigV1AKSConfig{
+				MaxPodsPerNode: lo.ToPtr[int32](60),
+			},
+			expected: []map[string]interface{}{{"max_pods_per_node": int32(60)}},
+		},
+		"no config": {},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, flattenAKSConfig(tc.config))
+		})
+	}
+}
@@ -2,16 +2,22 @@ package castai
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/castai/terraform-provider-castai/castai/sdk"
 	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/samber/lo"
 	"log"
+	"math"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -24,10 +30,13 @@ const (
 	FieldNodeTemplateCustomInstancesWithExtendedMemoryEnabled = "custom_instances_with_extended_memory_enabled"
 	FieldNodeTemplateCustomLabels                             = "custom_labels"
 	FieldNodeTemplateCustomTaints                             = "custom_taints"
+	FieldNodeTemplateDeleteNodes                              = "delete_nodes"
+	FieldNodeTemplateDrainTimeoutSeconds                      = "drain_timeout_seconds"
 	FieldNodeTemplateEnableSpotDiversity                      = "enable_spot_diversity"
 	FieldNodeTemplateExclude                                  = "exclude"
 	FieldNodeTemplateExcludeNames                             = "exclude_names"
 	FieldNodeTemplateFallbackRestoreRateSeconds               = "fallback_restore_rate_seconds"
+	FieldNodeTemplateForceDelete                              = "force_delete"
 	FieldNodeTemplateGpu                                      = "gpu"
 	FieldNodeTemplateInclude                                  = "include"
 	FieldNodeTemplateIncludeNames                             = "include_names"
@@ -44,7 +53,9 @@ const (
 	FieldNodeTemplateMinMemory                                = "min_memory"
 	FieldNodeTemplateName                                     = "name"
 	FieldNodeTemplateOnDemand                                 = "on_demand"
+	FieldNodeTemplateOnDestroy                                = "on_destroy"
 	FieldNodeTemplateOs                                       = "os"
+	FieldNodeTemplateParallelism                              = "parallelism"
 	FieldNodeTemplateRebalancingConfigMinNodes                = "rebalancing_config_min_nodes"
 	FieldNodeTemplateShouldTaint                              = "should_taint"
 	FieldNodeTemplateSpot                                     = "spot"
@@ -55,11 +66,19 @@ const (
 	FieldNodeTemplateUseSpotFallbacks                         = "use_spot_fallbacks"
 )
 
+// NodeTemplateLabelKey is the label CAST AI puts on nodes provisioned from a node template.
+const NodeTemplateLabelKey = "scheduling.cast.ai/node-template"
+
 const (
 	TaintEffectNoSchedule = "NoSchedule"
 	TaintEffectNoExecute  = "NoExecute"
 )
 
+const (
+	SpotInterruptionPredictionsTypeAWSRebalanceRecommendations = "aws-rebalance-recommendations"
+	SpotInterruptionPredictionsTypeInterruptionPredictions     = "interruption-predictions"
+)
+
 const (
 	ArchAMD64 = "amd64"
 	ArchARM64 = "arm64"
@@ -70,12 +89,14 @@ const (
 func resourceNodeTemplate() *schema.Resource {
 	supportedArchitectures := []string{ArchAMD64, ArchARM64}
 	supportedOs := []string{OsLinux, OsWindows}
+	supportedSpotInterruptionPredictionsTypes := []string{SpotInterruptionPredictionsTypeAWSRebalanceRecommendations, SpotInterruptionPredictionsTypeInterruptionPredictions}
 
 	return &schema.Resource{
 		CreateContext: resourceNodeTemplateCreate,
 		ReadContext:   resourceNodeTemplateRead,
 		DeleteContext: resourceNodeTemplateDelete,
 		UpdateContext: resourceNodeTemplateUpdate,
+		CustomizeDiff: nodeTemplateConstraintsDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: nodeTemplateStateImporter,
 		},
@@ -84,8 +105,9 @@ func resourceNodeTemplate() *schema.Resource {
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(1 * time.Minute),
 			Read:   schema.DefaultTimeout(1 * time.Minute),
-			Update: schema.DefaultTimeout(1 * time.Minute),
-			Delete: schema.DefaultTimeout(1 * time.Minute),
+			// Deleting nodes of the template on rename or destroy waits for nodes to be drained.
+			Update: schema.DefaultTimeout(15 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -98,9 +120,10 @@ func resourceNodeTemplate() *schema.Resource {
 			FieldNodeTemplateName: {
 				Type:             schema.TypeString,
 				Required:         true,
-				ForceNew:         true,
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
-				Description:      "Name of the node template.",
+				Description: "Name of the node template. Renaming creates a node template with the new name first and deletes the " +
+					"previous one afterwards the same way as on destroy, see `" + FieldNodeTemplateOnDestroy + "`. Nodes provisioned " +
+					"from the previous template keep its name in the `" + NodeTemplateLabelKey + "` label unless they are deleted.",
 			},
 			FieldNodeTemplateIsEnabled: {
 				Type:        schema.TypeBool,
@@ -175,8 +198,8 @@ func resourceNodeTemplate() *schema.Resource {
 						FieldNodeTemplateSpotInterruptionPredictionsType: {
 							Type:             schema.TypeString,
 							Optional:         true,
-							Description:      "Spot interruption predictions type. Can be either \"aws-rebalance-recommendations\" or \"interruption-predictions\".",
-							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"aws-rebalance-recommendations", "interruption-predictions"}, false)),
+							Description:      fmt.Sprintf("Spot interruption predictions type. Can be either %q or %q.", SpotInterruptionPredictionsTypeAWSRebalanceRecommendations, SpotInterruptionPredictionsTypeInterruptionPredictions),
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(supportedSpotInterruptionPredictionsTypes, false)),
 						},
 						FieldNodeTemplateMinCpu: {
 							Type:        schema.TypeInt,
@@ -272,14 +295,16 @@ func resourceNodeTemplate() *schema.Resource {
 										Description: "Names of the GPUs to exclude.",
 									},
 									FieldNodeTemplateMinCount: {
-										Type:        schema.TypeInt,
-										Optional:    true,
-										Description: "Min GPU count for the instance type to have.",
+										Type:             schema.TypeInt,
+										Optional:         true,
+										ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+										Description:      "Min GPU count for the instance type to have.",
 									},
 									FieldNodeTemplateMaxCount: {
-										Type:        schema.TypeInt,
-										Optional:    true,
-										Description: "Max GPU count for the instance type to have.",
+										Type:             schema.TypeInt,
+										Optional:         true,
+										ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+										Description:      "Max GPU count for the instance type to have.",
 									},
 								},
 							},
@@ -377,10 +402,183 @@ func resourceNodeTemplate() *schema.Resource {
 				Description: "Marks whether custom instances with extended memory should be used when deciding which parts of inventory are available. " +
 					"Custom instances are only supported in GCP.",
 			},
+			FieldNodeTemplateOnDestroy: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						FieldNodeTemplateDeleteNodes: {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+							Description: "Drain and delete nodes provisioned from this template before deleting the template. " +
+								"The template is deleted only after all of its nodes are gone, within the delete timeout.",
+						},
+						FieldNodeTemplateParallelism: {
+							Type:             schema.TypeInt,
+							Optional:         true,
+							Default:          1,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+							Description:      "Number of nodes drained and deleted at the same time.",
+						},
+						FieldNodeTemplateDrainTimeoutSeconds: {
+							Type:             schema.TypeInt,
+							Optional:         true,
+							Default:          600,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+							Description: "Node drain timeout in seconds. Nodes are deleted in batches of `" + FieldNodeTemplateParallelism + "`, " +
+								"the delete timeout of the resource should allow every batch to be drained.",
+						},
+						FieldNodeTemplateForceDelete: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Delete nodes even if they fail to be drained gracefully.",
+						},
+					},
+				},
+				Description: "Behavior of the node template on destroy. By default only the node template is deleted and nodes provisioned from it keep running.",
+			},
 		},
 	}
 }
 
+func nodeTemplateConstraintsDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	constraints, ok := d.Get(FieldNodeTemplateConstraints).([]any)
+	if !ok || len(constraints) == 0 || constraints[0] == nil {
+		return nil
+	}
+
+	// Values not known until apply, e.g. taken from other resources, are left out so that checks depending on them are skipped.
+	c := omitUnknownValues(d, FieldNodeTemplateConstraints+".0", constraints[0].(map[string]any))
+
+	return validateNodeTemplateConstraints(c, configuredOnDemand(d.GetRawConfig()))
+}
+
+// omitUnknownValues returns values of a block without the ones that are not known until apply, including nested blocks.
+func omitUnknownValues(d *schema.ResourceDiff, prefix string, values map[string]any) map[string]any {
+	result := make(map[string]any, len(values))
+	for field, value := range values {
+		key := prefix + "." + field
+		if !d.NewValueKnown(key) {
+			continue
+		}
+
+		if list, ok := value.([]any); ok {
+			known := make([]any, 0, len(list))
+			for i, item := range list {
+				itemKey := fmt.Sprintf("%s.%d", key, i)
+				if block, ok := item.(map[string]any); ok {
+					known = append(known, omitUnknownValues(d, itemKey, block))
+				} else if d.NewValueKnown(itemKey) {
+					known = append(known, item)
+				}
+			}
+			if len(known) != len(list) {
+				continue
+			}
+			value = known
+		}
+
+		result[field] = value
+	}
+	return result
+}
+
+// configuredOnDemand returns the on_demand constraint value only when it is explicitly set in the configuration,
+// as the attribute is computed and defaults to true on the server side.
+func configuredOnDemand(config cty.Value) *bool {
+	if config.IsNull() || !config.IsKnown() || !config.Type().HasAttribute(FieldNodeTemplateConstraints) {
+		return nil
+	}
+
+	constraints := config.GetAttr(FieldNodeTemplateConstraints)
+	if constraints.IsNull() || !constraints.IsKnown() || constraints.LengthInt() == 0 {
+		return nil
+	}
+
+	constraint := constraints.Index(cty.NumberIntVal(0))
+	if constraint.IsNull() || !constraint.Type().HasAttribute(FieldNodeTemplateOnDemand) {
+		return nil
+	}
+
+	onDemand := constraint.GetAttr(FieldNodeTemplateOnDemand)
+	if onDemand.IsNull() || !onDemand.IsKnown() {
+		return nil
+	}
+
+	return lo.ToPtr(onDemand.True())
+}
+
+func validateNodeTemplateConstraints(c map[string]any, onDemand *bool) error {
+	var result *multierror.Error
+
+	path := func(fields ...string) string {
+		return strings.Join(append([]string{FieldNodeTemplateConstraints, "0"}, fields...), ".")
+	}
+
+	minCpu, minCpuKnown := c[FieldNodeTemplateMinCpu].(int)
+	maxCpu, maxCpuKnown := c[FieldNodeTemplateMaxCpu].(int)
+	if minCpuKnown && maxCpuKnown && maxCpu != 0 && minCpu > maxCpu {
+		result = multierror.Append(result, fmt.Errorf("%s: must be less than or equal to %s (%d > %d)",
+			path(FieldNodeTemplateMinCpu), path(FieldNodeTemplateMaxCpu), minCpu, maxCpu))
+	}
+
+	minMemory, minMemoryKnown := c[FieldNodeTemplateMinMemory].(int)
+	maxMemory, maxMemoryKnown := c[FieldNodeTemplateMaxMemory].(int)
+	if minMemoryKnown && maxMemoryKnown && maxMemory != 0 && minMemory > maxMemory {
+		result = multierror.Append(result, fmt.Errorf("%s: must be less than or equal to %s (%d > %d)",
+			path(FieldNodeTemplateMinMemory), path(FieldNodeTemplateMaxMemory), minMemory, maxMemory))
+	}
+
+	spot, spotKnown := c[FieldNodeTemplateSpot].(bool)
+	if spotKnown && !spot && onDemand != nil && !*onDemand {
+		result = multierror.Append(result, fmt.Errorf("%s: at least one of %s or %s must be true",
+			path(FieldNodeTemplateOnDemand), path(FieldNodeTemplateSpot), path(FieldNodeTemplateOnDemand)))
+	}
+
+	if useSpotFallbacks, _ := c[FieldNodeTemplateUseSpotFallbacks].(bool); useSpotFallbacks && spotKnown && !spot {
+		result = multierror.Append(result, fmt.Errorf("%s: spot fallbacks can only be used when %s is true",
+			path(FieldNodeTemplateUseSpotFallbacks), path(FieldNodeTemplateSpot)))
+	}
+
+	if predictionsEnabled, _ := c[FieldNodeTemplateSpotInterruptionPredictionsEnabled].(bool); predictionsEnabled && spotKnown && !spot {
+		result = multierror.Append(result, fmt.Errorf("%s: spot interruption predictions can only be used when %s is true",
+			path(FieldNodeTemplateSpotInterruptionPredictionsEnabled), path(FieldNodeTemplateSpot)))
+	}
+
+	var gpu map[string]any
+	gpuList, gpuKnown := c[FieldNodeTemplateGpu].([]any)
+	if len(gpuList) > 0 {
+		gpu, _ = gpuList[0].(map[string]any)
+	}
+	if isGpuOnly, _ := c[FieldNodeTemplateIsGpuOnly].(bool); isGpuOnly && gpuKnown && gpu == nil {
+		result = multierror.Append(result, fmt.Errorf("%s: GPU only templates require a %s block",
+			path(FieldNodeTemplateIsGpuOnly), path(FieldNodeTemplateGpu)))
+	}
+	if gpu != nil {
+		minCount, minCountKnown := gpu[FieldNodeTemplateMinCount].(int)
+		maxCount, maxCountKnown := gpu[FieldNodeTemplateMaxCount].(int)
+		if minCountKnown && maxCountKnown && maxCount != 0 && minCount > maxCount {
+			result = multierror.Append(result, fmt.Errorf("%s: must be less than or equal to %s (%d > %d)",
+				path(FieldNodeTemplateGpu, "0", FieldNodeTemplateMinCount), path(FieldNodeTemplateGpu, "0", FieldNodeTemplateMaxCount), minCount, maxCount))
+		}
+	}
+
+	if v, ok := c[FieldNodeTemplateInstanceFamilies].([]any); ok && len(v) > 0 && v[0] != nil {
+		families := v[0].(map[string]any)
+		include := toStringList(lo.FromPtr(readOptionalValue[[]any](families, FieldNodeTemplateInclude)))
+		exclude := toStringList(lo.FromPtr(readOptionalValue[[]any](families, FieldNodeTemplateExclude)))
+		if overlap := lo.Intersect(include, exclude); len(overlap) > 0 {
+			result = multierror.Append(result, fmt.Errorf("%s: instance families %s are also listed in %s",
+				path(FieldNodeTemplateInstanceFamilies, "0", FieldNodeTemplateExclude), strings.Join(overlap, ", "), path(FieldNodeTemplateInstanceFamilies, "0", FieldNodeTemplateInclude)))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
 func resourceNodeTemplateRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	log.Printf("[INFO] List Node Templates get call start")
 	defer log.Printf("[INFO] List Node Templates get call end")
@@ -566,18 +764,215 @@ func resourceNodeTemplateDelete(ctx context.Context, d *schema.ResourceData, met
 		}
 	}
 
+	if err := deleteNodeTemplate(ctx, d, client, clusterID, name, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// deleteNodeTemplate deletes the node template, draining and deleting nodes provisioned from it first when configured
+// in on_destroy.
+func deleteNodeTemplate(ctx context.Context, d *schema.ResourceData, client *sdk.ClientWithResponses, clusterID, name string, timeout time.Duration) error {
+	// All waits share a single deadline, so that deleting nodes can't take longer than the timeout in total.
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if onDestroy := toSection(d, FieldNodeTemplateOnDestroy); onDestroy != nil && onDestroy[FieldNodeTemplateDeleteNodes].(bool) {
+		if err := deleteNodeTemplateNodes(ctx, client, clusterID, name, onDestroy); err != nil {
+			return fmt.Errorf("deleting nodes of node template %q: %w", name, err)
+		}
+	}
+
 	resp, err := client.NodeTemplatesAPIDeleteNodeTemplateWithResponse(ctx, clusterID, name)
 	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
-		return diag.FromErr(checkErr)
+		return fmt.Errorf("deleting node template %q: %w", name, checkErr)
+	}
+
+	return nil
+}
+
+// deleteNodeTemplateNodes drains and deletes nodes provisioned from the node template in batches and waits until
+// none of them are left in the cluster, within the deadline of the context.
+func deleteNodeTemplateNodes(ctx context.Context, client *sdk.ClientWithResponses, clusterID, name string, onDestroy map[string]any) error {
+	nodes, err := listNodeTemplateNodes(ctx, client, clusterID, name)
+	if err != nil {
+		return fmt.Errorf("listing nodes: %w", err)
+	}
+
+	parallelism := onDestroy[FieldNodeTemplateParallelism].(int)
+	drainTimeout := onDestroy[FieldNodeTemplateDrainTimeoutSeconds].(int)
+	force := onDestroy[FieldNodeTemplateForceDelete].(bool)
+
+	log.Printf("[INFO] Deleting %d node(s) of node template %q", len(nodes), name)
+
+	var result *multierror.Error
+	for _, batch := range lo.Chunk(nodes, parallelism) {
+		var (
+			wg sync.WaitGroup
+			mu sync.Mutex
+		)
+		for _, node := range batch {
+			wg.Add(1)
+			go func(nodeID string) {
+				defer wg.Done()
+				if err := drainAndDeleteNode(ctx, client, clusterID, nodeID, drainTimeout, force); err != nil {
+					mu.Lock()
+					result = multierror.Append(result, fmt.Errorf("node %s: %w", nodeID, err))
+					mu.Unlock()
+				}
+			}(lo.FromPtr(node.Id))
+		}
+		wg.Wait()
+	}
+	if err := result.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	return waitForNodeTemplateNodesRemoval(ctx, client, clusterID, name)
+}
+
+// drainAndDeleteNode deletes the node, which is drained by CAST AI before it is deleted.
+func drainAndDeleteNode(ctx context.Context, client *sdk.ClientWithResponses, clusterID, nodeID string, drainTimeout int, force bool) error {
+	resp, err := client.ExternalClusterAPIDeleteNodeWithResponse(ctx, clusterID, nodeID, &sdk.ExternalClusterAPIDeleteNodeParams{
+		DrainTimeout: lo.ToPtr(strconv.Itoa(drainTimeout)),
+		ForceDelete:  lo.ToPtr(force),
+	})
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return fmt.Errorf("deleting: %w", checkErr)
+	}
+	if err := waitForOperation(ctx, client, lo.FromPtr(resp.JSON200.OperationId)); err != nil {
+		return fmt.Errorf("deleting: %w", err)
 	}
 
 	return nil
 }
 
+func waitForOperation(ctx context.Context, client *sdk.ClientWithResponses, operationID string) error {
+	return retry.RetryContext(ctx, untilDeadline(ctx), func() *retry.RetryError {
+		resp, err := client.OperationsAPIGetOperationWithResponse(ctx, operationID)
+		if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+			return retry.NonRetryableError(checkErr)
+		}
+
+		if !lo.FromPtr(resp.JSON200.Done) {
+			return retry.RetryableError(fmt.Errorf("operation %s is still in progress", operationID))
+		}
+		if opErr := resp.JSON200.Error; opErr != nil {
+			return retry.NonRetryableError(fmt.Errorf("operation %s failed: %s: %s", operationID, lo.FromPtr(opErr.Reason), lo.FromPtr(opErr.Details)))
+		}
+		return nil
+	})
+}
+
+// untilDeadline returns the time left until the deadline of the context, used to bound retries by it.
+func untilDeadline(ctx context.Context) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return math.MaxInt64
+	}
+	return time.Until(deadline)
+}
+
 func resourceNodeTemplateUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	if d.HasChange(FieldNodeTemplateName) {
+		return renameNodeTemplate(ctx, d, meta)
+	}
+
 	return updateNodeTemplate(ctx, d, meta, false)
 }
 
+// renameNodeTemplate replaces the node template with one under the new name. Node templates are identified by name
+// only, so the new template is created before the previous one is deleted to keep the autoscaler from running
+// without a template.
+func renameNodeTemplate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ProviderConfig).api
+	clusterID := d.Get(FieldClusterID).(string)
+	oldValue, newValue := d.GetChange(FieldNodeTemplateName)
+	oldName, newName := oldValue.(string), newValue.(string)
+
+	if d.Get(FieldNodeTemplateIsDefault).(bool) {
+		return diag.Errorf("default node template %q cannot be renamed", oldName)
+	}
+
+	log.Printf("[INFO] Renaming node template %q to %q", oldName, newName)
+
+	exists, err := nodeTemplateExists(ctx, client, clusterID, newName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(newName)
+	// Template with the new name is left over from a previously interrupted rename.
+	if exists {
+		if diags := updateNodeTemplate(ctx, d, meta, true); diags.HasError() {
+			return diags
+		}
+	} else {
+		if diags := resourceNodeTemplateCreate(ctx, d, meta); diags.HasError() {
+			return diags
+		}
+	}
+
+	if err := deleteNodeTemplate(ctx, d, client, clusterID, oldName, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		// Keep the previous name in state, so that the rename is picked up again on the next apply.
+		d.SetId(oldName)
+		if err := d.Set(FieldNodeTemplateName, oldName); err != nil {
+			return diag.FromErr(fmt.Errorf("setting name: %w", err))
+		}
+		return diag.FromErr(fmt.Errorf("renaming node template %q to %q: %w", oldName, newName, err))
+	}
+
+	return nil
+}
+
+func nodeTemplateExists(ctx context.Context, client *sdk.ClientWithResponses, clusterID, name string) (bool, error) {
+	resp, err := client.NodeTemplatesAPIListNodeTemplatesWithResponse(ctx, clusterID, &sdk.NodeTemplatesAPIListNodeTemplatesParams{IncludeDefault: lo.ToPtr(true)})
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return false, checkErr
+	}
+
+	return lo.ContainsBy(lo.FromPtr(resp.JSON200.Items), func(t sdk.NodetemplatesV1NodeTemplateListItem) bool {
+		return t.Template != nil && lo.FromPtr(t.Template.Name) == name
+	}), nil
+}
+
+// listNodeTemplateNodes returns cluster nodes which were provisioned from the given node template.
+func listNodeTemplateNodes(ctx context.Context, client *sdk.ClientWithResponses, clusterID, name string) ([]sdk.ExternalclusterV1Node, error) {
+	var nodes []sdk.ExternalclusterV1Node
+	params := &sdk.ExternalClusterAPIListNodesParams{}
+	for {
+		resp, err := client.ExternalClusterAPIListNodesWithResponse(ctx, clusterID, params)
+		if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+			return nil, checkErr
+		}
+
+		for _, node := range lo.FromPtr(resp.JSON200.Items) {
+			if node.Labels != nil && node.Labels.AdditionalProperties[NodeTemplateLabelKey] == name {
+				nodes = append(nodes, node)
+			}
+		}
+
+		if lo.FromPtr(resp.JSON200.NextCursor) == "" {
+			return nodes, nil
+		}
+		params.PageCursor = resp.JSON200.NextCursor
+	}
+}
+
+func waitForNodeTemplateNodesRemoval(ctx context.Context, client *sdk.ClientWithResponses, clusterID, name string) error {
+	return retry.RetryContext(ctx, untilDeadline(ctx), func() *retry.RetryError {
+		nodes, err := listNodeTemplateNodes(ctx, client, clusterID, name)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		if len(nodes) > 0 {
+			return retry.RetryableError(fmt.Errorf("%d node(s) are still labeled with node template %q", len(nodes), name))
+		}
+		return nil
+	})
+}
+
 func updateNodeTemplate(ctx context.Context, d *schema.ResourceData, meta any, skipChangeCheck bool) diag.Diagnostics {
 	if !skipChangeCheck && !d.HasChanges(
 		FieldNodeTemplateName,
@@ -807,22 +1202,30 @@ func nodeTemplateStateImporter(ctx context.Context, d *schema.ResourceData, meta
 	if err := d.Set(FieldClusterID, clusterID); err != nil {
 		return nil, fmt.Errorf("setting cluster id: %w", err)
 	}
-	d.SetId(id)
-
-	// Return if node config ID provided.
-	if _, err := uuid.Parse(id); err == nil {
-		return []*schema.ResourceData{d}, nil
-	}
 
 	// Find node templates
 	client := meta.(*ProviderConfig).api
 	resp, err := client.NodeTemplatesAPIListNodeTemplatesWithResponse(ctx, clusterID, &sdk.NodeTemplatesAPIListNodeTemplatesParams{IncludeDefault: lo.ToPtr(true)})
-	if err != nil {
-		return nil, err
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return nil, checkErr
+	}
+
+	// Node templates are read by name, so IDs are resolved to names of the templates.
+	if _, err := uuid.Parse(id); err == nil {
+		names, err := nodeTemplateNamesByID(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		name, found := names[id]
+		if !found {
+			return nil, fmt.Errorf("failed to find node template with the following id: %v", id)
+		}
+		d.SetId(name)
+		return []*schema.ResourceData{d}, nil
 	}
 
 	if resp.JSON200 != nil {
-		for _, cfg := range *resp.JSON200.Items {
+		for _, cfg := range lo.FromPtr(resp.JSON200.Items) {
 			name := toString(cfg.Template.Name)
 			if name == id {
 				d.SetId(name)
@@ -834,6 +1237,30 @@ func nodeTemplateStateImporter(ctx context.Context, d *schema.ResourceData, meta
 	return nil, fmt.Errorf("failed to find node template with the following name: %v", id)
 }
 
+// nodeTemplateNamesByID maps IDs of node templates in the list response body to their names. Generated client doesn't
+// expose template IDs, so they are decoded from the raw body.
+func nodeTemplateNamesByID(body []byte) (map[string]string, error) {
+	var list struct {
+		Items []struct {
+			Template struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"template"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("decoding node templates: %w", err)
+	}
+
+	names := make(map[string]string, len(list.Items))
+	for _, item := range list.Items {
+		if item.Template.ID != "" {
+			names[item.Template.ID] = item.Template.Name
+		}
+	}
+	return names, nil
+}
+
 func toCustomTaintsWithOptionalEffect(objs []map[string]any) *[]sdk.NodetemplatesV1TaintWithOptionalEffect {
 	if len(objs) == 0 {
 		return nil
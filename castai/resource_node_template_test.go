@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/require"
 
@@ -358,6 +359,357 @@ func TestNodeTemplateResourceDelete_defaultNodeTemplate(t *testing.T) {
 		" false).", result[0].Detail)
 }
 
+func TestNodeTemplateResourceDelete_deleteNodes(t *testing.T) {
+	r := require.New(t)
+	mockctrl := gomock.NewController(t)
+	mockClient := mock_sdk.NewMockClientInterface(mockctrl)
+
+	ctx := context.Background()
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterId := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	jsonResponse := func(body string) *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(body))), Header: map[string][]string{"Content-Type": {"json"}}}
+	}
+
+	gomock.InOrder(
+		mockClient.EXPECT().
+			ExternalClusterAPIListNodes(gomock.Any(), clusterId, &sdk.ExternalClusterAPIListNodesParams{}).
+			Return(jsonResponse(`
+				{
+				  "items": [
+					{"id": "node-1", "labels": {"scheduling.cast.ai/node-template": "gpu"}},
+					{"id": "node-2", "labels": {"scheduling.cast.ai/node-template": "default-by-castai"}}
+				  ],
+				  "nextCursor": "next"
+				}`), nil),
+		mockClient.EXPECT().
+			ExternalClusterAPIListNodes(gomock.Any(), clusterId, &sdk.ExternalClusterAPIListNodesParams{PageCursor: lo.ToPtr("next")}).
+			Return(jsonResponse(`{"items": [{"id": "node-3", "labels": {"scheduling.cast.ai/node-template": "gpu"}}]}`), nil),
+	)
+
+	for _, nodeID := range []string{"node-1", "node-3"} {
+		mockClient.EXPECT().
+			ExternalClusterAPIDeleteNode(gomock.Any(), clusterId, nodeID, &sdk.ExternalClusterAPIDeleteNodeParams{
+				DrainTimeout: lo.ToPtr("300"),
+				ForceDelete:  lo.ToPtr(false),
+			}).
+			Return(jsonResponse(fmt.Sprintf(`{"operationId": "delete-%s"}`, nodeID)), nil)
+		mockClient.EXPECT().
+			OperationsAPIGetOperation(gomock.Any(), "delete-"+nodeID).
+			Return(jsonResponse(`{"done": true}`), nil)
+	}
+
+	mockClient.EXPECT().
+		ExternalClusterAPIListNodes(gomock.Any(), clusterId, &sdk.ExternalClusterAPIListNodesParams{}).
+		Return(jsonResponse(`{"items": [{"id": "node-2", "labels": {"scheduling.cast.ai/node-template": "default-by-castai"}}]}`), nil)
+	mockClient.EXPECT().
+		NodeTemplatesAPIDeleteNodeTemplate(gomock.Any(), clusterId, "gpu").
+		Return(jsonResponse(`{}`), nil)
+
+	resource := resourceNodeTemplate()
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterId:        cty.StringVal(clusterId),
+		FieldNodeTemplateName: cty.StringVal("gpu"),
+		FieldNodeTemplateOnDestroy: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			FieldNodeTemplateDeleteNodes:         cty.True,
+			FieldNodeTemplateParallelism:         cty.NumberIntVal(2),
+			FieldNodeTemplateDrainTimeoutSeconds: cty.NumberIntVal(300),
+			FieldNodeTemplateForceDelete:         cty.False,
+		})}),
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+	state.ID = "gpu"
+
+	data := resource.Data(state)
+	result := resource.DeleteContext(ctx, data, provider)
+	r.Nil(result)
+}
+
+func TestNodeTemplateResourceUpdate_rename(t *testing.T) {
+	r := require.New(t)
+	mockctrl := gomock.NewController(t)
+	mockClient := mock_sdk.NewMockClientInterface(mockctrl)
+
+	ctx := context.Background()
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterId := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	listResponse := func(name string) *http.Response {
+		body := io.NopCloser(bytes.NewReader([]byte(fmt.Sprintf(`
+		{
+		  "items": [
+			{
+			  "template": {
+				"configurationId": "7dc4f922-29c9-4377-889c-0c8c5fb8d497",
+				"name": %q,
+				"isEnabled": true,
+				"shouldTaint": true,
+				"customLabels": {},
+				"customTaints": []
+			  }
+			}
+		  ]
+		}
+	`, name))))
+		return &http.Response{StatusCode: 200, Body: body, Header: map[string][]string{"Content-Type": {"json"}}}
+	}
+
+	gomock.InOrder(
+		mockClient.EXPECT().
+			NodeTemplatesAPIListNodeTemplates(gomock.Any(), clusterId, &sdk.NodeTemplatesAPIListNodeTemplatesParams{IncludeDefault: lo.ToPtr(true)}).
+			Return(listResponse("gpu"), nil),
+		mockClient.EXPECT().
+			NodeTemplatesAPICreateNodeTemplate(gomock.Any(), clusterId, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, req sdk.NodeTemplatesAPICreateNodeTemplateJSONRequestBody) (*http.Response, error) {
+				r.Equal("gpu-v2", lo.FromPtr(req.Name))
+				body := io.NopCloser(bytes.NewReader([]byte(`{"name": "gpu-v2"}`)))
+				return &http.Response{StatusCode: 200, Body: body, Header: map[string][]string{"Content-Type": {"json"}}}, nil
+			}),
+		mockClient.EXPECT().
+			NodeTemplatesAPIListNodeTemplates(gomock.Any(), clusterId, &sdk.NodeTemplatesAPIListNodeTemplatesParams{IncludeDefault: lo.ToPtr(true)}).
+			Return(listResponse("gpu-v2"), nil),
+		// Nodes of the previous template are deleted as configured in on_destroy, only nodes of the new one are left.
+		mockClient.EXPECT().
+			ExternalClusterAPIListNodes(gomock.Any(), clusterId, &sdk.ExternalClusterAPIListNodesParams{}).
+			DoAndReturn(func(context.Context, string, *sdk.ExternalClusterAPIListNodesParams, ...sdk.RequestEditorFn) (*http.Response, error) {
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`
+					{
+					  "items": [
+						{
+						  "id": "3a1b2c4d-0000-4000-8000-000000000001",
+						  "labels": {"scheduling.cast.ai/node-template": "gpu-v2"}
+						}
+					  ]
+					}
+				`))), Header: map[string][]string{"Content-Type": {"json"}}}, nil
+			}).
+			Times(2),
+		mockClient.EXPECT().
+			NodeTemplatesAPIDeleteNodeTemplate(gomock.Any(), clusterId, "gpu").
+			Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{}`)))}, nil),
+	)
+
+	resource := resourceNodeTemplate()
+	state := terraform.NewInstanceStateShimmedFromValue(cty.ObjectVal(map[string]cty.Value{
+		FieldClusterId:        cty.StringVal(clusterId),
+		FieldNodeTemplateName: cty.StringVal("gpu"),
+	}), 0)
+	state.ID = "gpu"
+
+	config := terraform.NewResourceConfigRaw(map[string]any{
+		FieldClusterId:               clusterId,
+		FieldNodeTemplateName:        "gpu-v2",
+		FieldNodeTemplateShouldTaint: true,
+		FieldNodeTemplateOnDestroy: []any{map[string]any{
+			FieldNodeTemplateDeleteNodes: true,
+		}},
+	})
+	diff, err := resource.Diff(ctx, state, config, provider)
+	r.NoError(err)
+	r.False(diff.RequiresNew())
+
+	data, err := schema.InternalMap(resource.Schema).Data(state, diff)
+	r.NoError(err)
+
+	result := resource.UpdateContext(ctx, data, provider)
+	r.Nil(result)
+	r.Equal("gpu-v2", data.Id())
+	r.Equal("gpu-v2", data.Get(FieldNodeTemplateName))
+}
+
+func TestNodeTemplateResourceImport(t *testing.T) {
+	clusterId := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	body := `
+		{
+		  "items": [
+			{"template": {"id": "0d5e8a7c-4f1b-4c2a-9e3d-6b7a8c9d0e1f", "name": "default-by-castai", "isDefault": true}},
+			{"template": {"id": "5a4b3c2d-1e0f-4a9b-8c7d-6e5f4a3b2c1d", "name": "gpu"}}
+		  ]
+		}
+	`
+
+	tt := map[string]struct {
+		importID string
+		expectID string
+		errMsg   string
+	}{
+		"by name": {
+			importID: clusterId + "/gpu",
+			expectID: "gpu",
+		},
+		"by id": {
+			importID: clusterId + "/5a4b3c2d-1e0f-4a9b-8c7d-6e5f4a3b2c1d",
+			expectID: "gpu",
+		},
+		"unknown name": {
+			importID: clusterId + "/spot",
+			errMsg:   "failed to find node template with the following name: spot",
+		},
+		"unknown id": {
+			importID: clusterId + "/9f8e7d6c-5b4a-4392-8170-6f5e4d3c2b1a",
+			errMsg:   "failed to find node template with the following id: 9f8e7d6c-5b4a-4392-8170-6f5e4d3c2b1a",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+			provider := &ProviderConfig{
+				api: &sdk.ClientWithResponses{
+					ClientInterface: mockClient,
+				},
+			}
+
+			mockClient.EXPECT().
+				NodeTemplatesAPIListNodeTemplates(gomock.Any(), clusterId, &sdk.NodeTemplatesAPIListNodeTemplatesParams{IncludeDefault: lo.ToPtr(true)}).
+				Return(&http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(body))), Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+
+			resource := resourceNodeTemplate()
+			data := resource.Data(&terraform.InstanceState{ID: tc.importID})
+
+			result, err := resource.Importer.StateContext(context.Background(), data, provider)
+			if tc.errMsg != "" {
+				r.Error(err)
+				r.Contains(err.Error(), tc.errMsg)
+				return
+			}
+			r.NoError(err)
+			r.Len(result, 1)
+			r.Equal(tc.expectID, result[0].Id())
+			r.Equal(clusterId, result[0].Get(FieldClusterId))
+		})
+	}
+}
+
+func TestNodeTemplateResourceCustomizeDiff(t *testing.T) {
+	tests := map[string]struct {
+		constraints map[string]cty.Value
+		errors      []string
+	}{
+		"valid constraints": {
+			constraints: map[string]cty.Value{
+				FieldNodeTemplateSpot:             cty.True,
+				FieldNodeTemplateOnDemand:         cty.False,
+				FieldNodeTemplateUseSpotFallbacks: cty.True,
+				FieldNodeTemplateMinCpu:           cty.NumberIntVal(2),
+				FieldNodeTemplateMaxCpu:           cty.NumberIntVal(8),
+				FieldNodeTemplateMinMemory:        cty.NumberIntVal(2048),
+				FieldNodeTemplateMaxMemory:        cty.NumberIntVal(4096),
+				FieldNodeTemplateInstanceFamilies: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					FieldNodeTemplateInclude: cty.ListVal([]cty.Value{cty.StringVal("c5")}),
+					FieldNodeTemplateExclude: cty.ListVal([]cty.Value{cty.StringVal("m5")}),
+				})}),
+			},
+		},
+		"min values greater than max values": {
+			constraints: map[string]cty.Value{
+				FieldNodeTemplateMinCpu:    cty.NumberIntVal(16),
+				FieldNodeTemplateMaxCpu:    cty.NumberIntVal(8),
+				FieldNodeTemplateMinMemory: cty.NumberIntVal(8192),
+				FieldNodeTemplateMaxMemory: cty.NumberIntVal(4096),
+				FieldNodeTemplateGpu: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					FieldNodeTemplateMinCount: cty.NumberIntVal(4),
+					FieldNodeTemplateMaxCount: cty.NumberIntVal(2),
+				})}),
+			},
+			errors: []string{
+				"constraints.0.min_cpu: must be less than or equal to constraints.0.max_cpu (16 > 8)",
+				"constraints.0.min_memory: must be less than or equal to constraints.0.max_memory (8192 > 4096)",
+				"constraints.0.gpu.0.min_count: must be less than or equal to constraints.0.gpu.0.max_count (4 > 2)",
+			},
+		},
+		"neither spot nor on-demand": {
+			constraints: map[string]cty.Value{
+				FieldNodeTemplateSpot:     cty.False,
+				FieldNodeTemplateOnDemand: cty.False,
+			},
+			errors: []string{
+				"constraints.0.on_demand: at least one of constraints.0.spot or constraints.0.on_demand must be true",
+			},
+		},
+		"spot only features without spot": {
+			constraints: map[string]cty.Value{
+				FieldNodeTemplateUseSpotFallbacks:                   cty.True,
+				FieldNodeTemplateSpotInterruptionPredictionsEnabled: cty.True,
+			},
+			errors: []string{
+				"constraints.0.use_spot_fallbacks: spot fallbacks can only be used when constraints.0.spot is true",
+				"constraints.0.spot_interruption_predictions_enabled: spot interruption predictions can only be used when constraints.0.spot is true",
+			},
+		},
+		"values unknown until apply": {
+			constraints: map[string]cty.Value{
+				FieldNodeTemplateSpot:                               cty.UnknownVal(cty.Bool),
+				FieldNodeTemplateOnDemand:                           cty.False,
+				FieldNodeTemplateUseSpotFallbacks:                   cty.True,
+				FieldNodeTemplateSpotInterruptionPredictionsEnabled: cty.True,
+				FieldNodeTemplateMinCpu:                             cty.UnknownVal(cty.Number),
+				FieldNodeTemplateMaxCpu:                             cty.NumberIntVal(2),
+				FieldNodeTemplateInstanceFamilies: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					FieldNodeTemplateInclude: cty.ListVal([]cty.Value{cty.UnknownVal(cty.String)}),
+					FieldNodeTemplateExclude: cty.ListVal([]cty.Value{cty.StringVal("m5")}),
+				})}),
+			},
+		},
+		"gpu only without gpu block": {
+			constraints: map[string]cty.Value{
+				FieldNodeTemplateIsGpuOnly: cty.True,
+			},
+			errors: []string{
+				"constraints.0.is_gpu_only: GPU only templates require a constraints.0.gpu block",
+			},
+		},
+		"overlapping instance families": {
+			constraints: map[string]cty.Value{
+				FieldNodeTemplateInstanceFamilies: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					FieldNodeTemplateInclude: cty.ListVal([]cty.Value{cty.StringVal("c5"), cty.StringVal("m5")}),
+					FieldNodeTemplateExclude: cty.ListVal([]cty.Value{cty.StringVal("m5")}),
+				})}),
+			},
+			errors: []string{
+				"constraints.0.instance_families.0.exclude: instance families m5 are also listed in constraints.0.instance_families.0.include",
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			resource := resourceNodeTemplate()
+
+			val := cty.ObjectVal(map[string]cty.Value{
+				FieldClusterId:               cty.StringVal("b6bfc074-a267-400f-b8f1-db0850c369b1"),
+				FieldNodeTemplateName:        cty.StringVal("template"),
+				FieldNodeTemplateConstraints: cty.ListVal([]cty.Value{cty.ObjectVal(tt.constraints)}),
+			})
+			state := &terraform.InstanceState{RawConfig: val}
+			config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+			_, err := resource.Diff(context.Background(), state, config, nil)
+			if len(tt.errors) == 0 {
+				r.NoError(err)
+				return
+			}
+
+			r.Error(err)
+			for _, e := range tt.errors {
+				r.Contains(err.Error(), e)
+			}
+		})
+	}
+}
+
 func TestAccResourceNodeTemplate_basic(t *testing.T) {
 	rName := fmt.Sprintf("%v-node-template-%v", ResourcePrefix, acctest.RandString(8))
 	resourceName := "castai_node_template.test"
@@ -47,10 +47,32 @@ func resourceRebalancingJob() *schema.Resource {
 				Description:      "Rebalancing schedule of this job.",
 			},
 			"enabled": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     true,
-				Description: "The job will only be executed if it's enabled.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				Description: "The job will only be executed if it's enabled. " +
+					"Schedules don't disable their jobs during `blackout_windows` or after one-shot `at` trigger, " +
+					"set it to `!castai_rebalancing_schedule.<name>.suspended` for the jobs to be disabled and enabled back on apply.",
+			},
+			"last_trigger_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the job was last triggered at, in RFC 3339 format.",
+			},
+			"next_trigger_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the job is scheduled to be triggered at next, in RFC 3339 format.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the last execution of the job.",
+			},
+			"rebalancing_plan_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the rebalancing plan created by the last execution of the job.",
 			},
 		},
 	}
@@ -178,10 +200,32 @@ func rebalancingJobToState(job *sdk.ScheduledrebalancingV1RebalancingJob, d *sch
 	if err := d.Set("enabled", job.Enabled); err != nil {
 		return err
 	}
+	for k, v := range rebalancingJobStatusToState(job) {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
+// rebalancingJobStatusToState flattens the read-only execution details of the job.
+func rebalancingJobStatusToState(job *sdk.ScheduledrebalancingV1RebalancingJob) map[string]any {
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	return map[string]any{
+		"last_trigger_at":     formatTime(job.LastTriggerAt),
+		"next_trigger_at":     formatTime(job.NextTriggerAt),
+		"status":              string(lo.FromPtr(job.Status)),
+		"rebalancing_plan_id": lo.FromPtr(job.RebalancingPlanId),
+	}
+}
+
 func getRebalancingJobByScheduleName(ctx context.Context, client *sdk.ClientWithResponses, clusterID string, scheduleName string) (*sdk.ScheduledrebalancingV1RebalancingJob, error) {
 	schedule, err := getRebalancingScheduleByName(ctx, client, scheduleName)
 	if err != nil {
@@ -0,0 +1,70 @@
+package castai
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+func TestRebalancingJobResourceReadContext(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+
+	ctx := context.Background()
+	provider := &ProviderConfig{
+		api: &sdk.ClientWithResponses{
+			ClientInterface: mockClient,
+		},
+	}
+
+	clusterId := "b6bfc074-a267-400f-b8f1-db0850c369b1"
+	jobId := "2a6c0f4e-3b1d-4c8e-9f7a-5d2e1b0c9a87"
+	body := io.NopCloser(bytes.NewReader([]byte(`
+		{
+		  "id": "2a6c0f4e-3b1d-4c8e-9f7a-5d2e1b0c9a87",
+		  "clusterId": "b6bfc074-a267-400f-b8f1-db0850c369b1",
+		  "rebalancingScheduleId": "9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c",
+		  "enabled": true,
+		  "lastTriggerAt": "2024-03-08T12:00:00.123Z",
+		  "nextTriggerAt": "2024-03-09T12:00:00Z",
+		  "status": "JobStatusFinished",
+		  "rebalancingPlanId": "c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f"
+		}
+	`)))
+	mockClient.EXPECT().
+		ScheduledRebalancingAPIGetRebalancingJob(gomock.Any(), clusterId, jobId).
+		Return(&http.Response{StatusCode: 200, Body: body, Header: map[string][]string{"Content-Type": {"json"}}}, nil)
+
+	resource := resourceRebalancingJob()
+	val := cty.ObjectVal(map[string]cty.Value{
+		FieldClusterId: cty.StringVal(clusterId),
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+	state.ID = jobId
+	data := resource.Data(state)
+
+	result := resource.ReadContext(ctx, data, provider)
+	r.Nil(result)
+	r.False(result.HasError())
+
+	r.Equal(`ID = 2a6c0f4e-3b1d-4c8e-9f7a-5d2e1b0c9a87
+cluster_id = b6bfc074-a267-400f-b8f1-db0850c369b1
+enabled = true
+last_trigger_at = 2024-03-08T12:00:00Z
+next_trigger_at = 2024-03-09T12:00:00Z
+rebalancing_plan_id = c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f
+rebalancing_schedule_id = 9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c
+status = JobStatusFinished
+Tainted = false
+`, data.State().String())
+}
@@ -0,0 +1,427 @@
+package castai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+)
+
+const (
+	FieldRebalancingJobsScheduleID        = "rebalancing_schedule_id"
+	FieldRebalancingJobsEnabled           = "enabled"
+	FieldRebalancingJobsClusterSelector   = "cluster_selector"
+	FieldRebalancingJobsClusterIDs        = "cluster_ids"
+	FieldRebalancingJobsProviderTypes     = "provider_types"
+	FieldRebalancingJobsRegions           = "regions"
+	FieldRebalancingJobsNameRegex         = "name_regex"
+	FieldRebalancingJobsMatchedClusterIDs = "matched_cluster_ids"
+	FieldRebalancingJobsJobIDs            = "job_ids"
+	FieldRebalancingJobsOutOfSyncIDs      = "out_of_sync_cluster_ids"
+)
+
+func resourceRebalancingJobs() *schema.Resource {
+	selectorPaths := lo.Map([]string{
+		FieldRebalancingJobsClusterIDs,
+		FieldRebalancingJobsProviderTypes,
+		FieldRebalancingJobsRegions,
+		FieldRebalancingJobsNameRegex,
+	}, func(field string, _ int) string {
+		return FieldRebalancingJobsClusterSelector + ".0." + field
+	})
+
+	return &schema.Resource{
+		CreateContext: resourceRebalancingJobsCreate,
+		ReadContext:   resourceRebalancingJobsRead,
+		UpdateContext: resourceRebalancingJobsUpdate,
+		DeleteContext: resourceRebalancingJobsDelete,
+		CustomizeDiff: rebalancingJobsDiff,
+		Description: "Assigns a rebalancing schedule to every cluster matching the selector. " +
+			"Clusters are matched at plan time, so newly connected clusters get a job on the next apply. " +
+			"Only jobs created by the resource are managed: a cluster which already has a job of the schedule, e.g. from `castai_rebalancing_job`, is reported as failed.",
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			FieldRebalancingJobsScheduleID: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsUUID),
+				Description:      "Rebalancing schedule of the jobs.",
+			},
+			FieldRebalancingJobsEnabled: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				Description: "The jobs will only be executed if they are enabled. " +
+					"Schedules don't disable their jobs during `blackout_windows` or after one-shot `at` trigger, " +
+					"set it to `!castai_rebalancing_schedule.<name>.suspended` for the jobs to be disabled and enabled back on apply.",
+			},
+			FieldRebalancingJobsClusterSelector: {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						FieldRebalancingJobsClusterIDs: {
+							Type:         schema.TypeSet,
+							Optional:     true,
+							Elem:         &schema.Schema{Type: schema.TypeString, ValidateDiagFunc: validation.ToDiagFunc(validation.IsUUID)},
+							AtLeastOneOf: selectorPaths,
+							Description:  "CAST AI cluster ids to be selected regardless of other filters.",
+						},
+						FieldRebalancingJobsProviderTypes: {
+							Type:         schema.TypeSet,
+							Optional:     true,
+							Elem:         &schema.Schema{Type: schema.TypeString, ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace)},
+							AtLeastOneOf: selectorPaths,
+							Description:  "Cloud provider types of clusters to select, e.g. eks, gke or aks.",
+						},
+						FieldRebalancingJobsRegions: {
+							Type:         schema.TypeSet,
+							Optional:     true,
+							Elem:         &schema.Schema{Type: schema.TypeString, ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace)},
+							AtLeastOneOf: selectorPaths,
+							Description:  "Regions of clusters to select, e.g. eu-central-1.",
+						},
+						FieldRebalancingJobsNameRegex: {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+							AtLeastOneOf:     selectorPaths,
+							Description:      "Regular expression cluster names have to match.",
+						},
+					},
+				},
+				Description: "Selector of clusters to assign the schedule to. A cluster is selected when it is listed in `cluster_ids`, " +
+					"or when it matches all of `provider_types`, `regions` and `name_regex` which are set.",
+			},
+			FieldRebalancingJobsMatchedClusterIDs: {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "CAST AI cluster ids the schedule is assigned to.",
+			},
+			FieldRebalancingJobsJobIDs: {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Rebalancing job ids by CAST AI cluster id.",
+			},
+			FieldRebalancingJobsOutOfSyncIDs: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "CAST AI cluster ids whose job was enabled or disabled outside of Terraform. " +
+					"The jobs are updated to match `enabled` on the next apply.",
+			},
+		},
+	}
+}
+
+func rebalancingJobsDiff(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	// Jobs which drifted are updated on apply, after which none are out of sync.
+	if d.Get(FieldRebalancingJobsOutOfSyncIDs).(*schema.Set).Len() > 0 {
+		if err := d.SetNew(FieldRebalancingJobsOutOfSyncIDs, []string{}); err != nil {
+			return err
+		}
+	}
+
+	if !d.NewValueKnown(FieldRebalancingJobsClusterSelector) {
+		return setNewRebalancingJobsComputed(d)
+	}
+	for _, field := range []string{FieldRebalancingJobsClusterIDs, FieldRebalancingJobsProviderTypes, FieldRebalancingJobsRegions, FieldRebalancingJobsNameRegex} {
+		if !d.NewValueKnown(FieldRebalancingJobsClusterSelector + ".0." + field) {
+			return setNewRebalancingJobsComputed(d)
+		}
+	}
+
+	selected, err := selectRebalancingJobsClusters(ctx, meta.(*ProviderConfig).api, d.Get(FieldRebalancingJobsClusterSelector).([]any))
+	if err != nil {
+		return err
+	}
+
+	current := toStringList(d.Get(FieldRebalancingJobsMatchedClusterIDs).(*schema.Set).List())
+	sort.Strings(current)
+	if lo.Every(current, selected) && lo.Every(selected, current) {
+		return nil
+	}
+	if err := d.SetNew(FieldRebalancingJobsMatchedClusterIDs, selected); err != nil {
+		return err
+	}
+	return d.SetNewComputed(FieldRebalancingJobsJobIDs)
+}
+
+func setNewRebalancingJobsComputed(d *schema.ResourceDiff) error {
+	if err := d.SetNewComputed(FieldRebalancingJobsMatchedClusterIDs); err != nil {
+		return err
+	}
+	return d.SetNewComputed(FieldRebalancingJobsJobIDs)
+}
+
+func resourceRebalancingJobsCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	// Several resources can assign the same schedule, so the id isn't derived from it.
+	d.SetId(uuid.NewString())
+	return reconcileRebalancingJobs(ctx, d, meta)
+}
+
+func resourceRebalancingJobsUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	return reconcileRebalancingJobs(ctx, d, meta)
+}
+
+func resourceRebalancingJobsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ProviderConfig).api
+	enabled := d.Get(FieldRebalancingJobsEnabled).(bool)
+
+	var diags diag.Diagnostics
+	outOfSync := []string{}
+	jobIDs := map[string]string{}
+	for clusterID, jobID := range d.Get(FieldRebalancingJobsJobIDs).(map[string]any) {
+		job, err := findRebalancingJob(ctx, client, clusterID, jobID.(string))
+		if err != nil {
+			diags = append(diags, rebalancingJobsClusterDiagnostic(diag.Error, clusterID, "reading rebalancing job", err))
+			// Keep the job in state, it will be checked again on the next refresh.
+			jobIDs[clusterID] = jobID.(string)
+			continue
+		}
+		if job == nil {
+			tflog.Warn(ctx, "Rebalancing job not found, removing from state", map[string]any{"cluster_id": clusterID, "job_id": jobID})
+			continue
+		}
+		jobIDs[clusterID] = lo.FromPtr(job.Id)
+		if lo.FromPtr(job.Enabled) != enabled {
+			outOfSync = append(outOfSync, clusterID)
+		}
+	}
+
+	if err := d.Set(FieldRebalancingJobsOutOfSyncIDs, outOfSync); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if err := setRebalancingJobsState(d, jobIDs); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
+func resourceRebalancingJobsDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ProviderConfig).api
+
+	var diags diag.Diagnostics
+	jobIDs := map[string]string{}
+	for clusterID, jobID := range d.Get(FieldRebalancingJobsJobIDs).(map[string]any) {
+		if err := deleteRebalancingJob(ctx, client, clusterID, jobID.(string)); err != nil {
+			diags = append(diags, rebalancingJobsClusterDiagnostic(diag.Error, clusterID, "deleting rebalancing job", err))
+			jobIDs[clusterID] = jobID.(string)
+		}
+	}
+
+	// Jobs which failed to be deleted are kept in state, so deletion can be retried.
+	if diags.HasError() {
+		if err := setRebalancingJobsState(d, jobIDs); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+	return diags
+}
+
+// reconcileRebalancingJobs makes sure every selected cluster has an enabled or disabled job for the schedule
+// and deletes jobs of the clusters which are not selected anymore. Only jobs created by the resource are managed.
+// Failures are reported per cluster as warnings, without stopping reconciliation of the other clusters: failed clusters
+// are left out of state, so the next plan retries them. Errors would taint the resource on create instead,
+// and the jobs which were created would be deleted on the next apply.
+func reconcileRebalancingJobs(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ProviderConfig).api
+	scheduleID := d.Get(FieldRebalancingJobsScheduleID).(string)
+	enabled := d.Get(FieldRebalancingJobsEnabled).(bool)
+	ownedJobIDs := d.Get(FieldRebalancingJobsJobIDs).(map[string]any)
+
+	selected, err := selectRebalancingJobsClusters(ctx, client, d.Get(FieldRebalancingJobsClusterSelector).([]any))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	jobIDs := map[string]string{}
+	for _, clusterID := range selected {
+		ownedJobID, _ := ownedJobIDs[clusterID].(string)
+		job, err := ensureClusterRebalancingJob(ctx, client, clusterID, scheduleID, ownedJobID, enabled)
+		if err != nil {
+			diags = append(diags, rebalancingJobsClusterDiagnostic(diag.Warning, clusterID, "assigning rebalancing schedule", err))
+			continue
+		}
+		jobIDs[clusterID] = lo.FromPtr(job.Id)
+	}
+
+	for clusterID, jobID := range ownedJobIDs {
+		if lo.Contains(selected, clusterID) {
+			continue
+		}
+		if err := deleteRebalancingJob(ctx, client, clusterID, jobID.(string)); err != nil {
+			diags = append(diags, rebalancingJobsClusterDiagnostic(diag.Warning, clusterID, "deleting rebalancing job", err))
+			jobIDs[clusterID] = jobID.(string)
+		}
+	}
+
+	if err := setRebalancingJobsState(d, jobIDs); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if err := d.Set(FieldRebalancingJobsOutOfSyncIDs, []string{}); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
+// ensureClusterRebalancingJob makes sure the cluster has the job of the schedule created by the resource, tracked by jobID,
+// with the given enabled flag. Jobs of the schedule created by other means are not adopted.
+func ensureClusterRebalancingJob(ctx context.Context, client *sdk.ClientWithResponses, clusterID, scheduleID, jobID string, enabled bool) (*sdk.ScheduledrebalancingV1RebalancingJob, error) {
+	jobs, err := listClusterRebalancingJobs(ctx, client, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	job, owned := lo.Find(jobs, func(job sdk.ScheduledrebalancingV1RebalancingJob) bool {
+		return jobID != "" && lo.FromPtr(job.Id) == jobID
+	})
+	if !owned {
+		if existing, ok := lo.Find(jobs, func(job sdk.ScheduledrebalancingV1RebalancingJob) bool {
+			return lo.FromPtr(job.RebalancingScheduleId) == scheduleID
+		}); ok {
+			return nil, fmt.Errorf("cluster already has rebalancing job %s of the schedule which is not managed by this resource", lo.FromPtr(existing.Id))
+		}
+
+		resp, err := client.ScheduledRebalancingAPICreateRebalancingJobWithResponse(ctx, clusterID, sdk.ScheduledRebalancingAPICreateRebalancingJobJSONRequestBody{
+			ClusterId:             lo.ToPtr(clusterID),
+			RebalancingScheduleId: lo.ToPtr(scheduleID),
+			Enabled:               lo.ToPtr(enabled),
+		})
+		if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+			return nil, checkErr
+		}
+		return resp.JSON200, nil
+	}
+
+	if lo.FromPtr(job.Enabled) != enabled {
+		resp, err := client.ScheduledRebalancingAPIUpdateRebalancingJobWithResponse(ctx, clusterID, lo.FromPtr(job.Id), sdk.ScheduledRebalancingAPIUpdateRebalancingJobJSONRequestBody{
+			Enabled: lo.ToPtr(enabled),
+		})
+		if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+			return nil, checkErr
+		}
+	}
+	return &job, nil
+}
+
+// findRebalancingJob returns job of the cluster by id, or nil when there is none.
+func findRebalancingJob(ctx context.Context, client *sdk.ClientWithResponses, clusterID, jobID string) (*sdk.ScheduledrebalancingV1RebalancingJob, error) {
+	jobs, err := listClusterRebalancingJobs(ctx, client, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	job, ok := lo.Find(jobs, func(job sdk.ScheduledrebalancingV1RebalancingJob) bool {
+		return lo.FromPtr(job.Id) == jobID
+	})
+	if !ok {
+		return nil, nil
+	}
+	return &job, nil
+}
+
+// listClusterRebalancingJobs returns jobs of the cluster, or none when the cluster is not found.
+func listClusterRebalancingJobs(ctx context.Context, client *sdk.ClientWithResponses, clusterID string) ([]sdk.ScheduledrebalancingV1RebalancingJob, error) {
+	resp, err := client.ScheduledRebalancingAPIListRebalancingJobsWithResponse(ctx, clusterID)
+	if err == nil && resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return nil, checkErr
+	}
+	return lo.FromPtr(resp.JSON200.Jobs), nil
+}
+
+func deleteRebalancingJob(ctx context.Context, client *sdk.ClientWithResponses, clusterID, jobID string) error {
+	resp, err := client.ScheduledRebalancingAPIDeleteRebalancingJobWithResponse(ctx, clusterID, jobID)
+	if err == nil && resp.StatusCode() == http.StatusNotFound {
+		return nil
+	}
+	return sdk.CheckOKResponse(resp, err)
+}
+
+// selectRebalancingJobsClusters returns sorted ids of the clusters matching the cluster selector.
+func selectRebalancingJobsClusters(ctx context.Context, client *sdk.ClientWithResponses, selectorData []any) ([]string, error) {
+	if len(selectorData) == 0 || selectorData[0] == nil {
+		return []string{}, nil
+	}
+	selector := selectorData[0].(map[string]any)
+
+	clusterIDs := toStringList(selector[FieldRebalancingJobsClusterIDs].(*schema.Set).List())
+	providerTypes := toStringList(selector[FieldRebalancingJobsProviderTypes].(*schema.Set).List())
+	regions := toStringList(selector[FieldRebalancingJobsRegions].(*schema.Set).List())
+	var nameRegex *regexp.Regexp
+	if v, _ := selector[FieldRebalancingJobsNameRegex].(string); v != "" {
+		var err error
+		if nameRegex, err = regexp.Compile(v); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", FieldRebalancingJobsNameRegex, err)
+		}
+	}
+	filtered := len(providerTypes) > 0 || len(regions) > 0 || nameRegex != nil
+
+	selected := clusterIDs
+	if filtered {
+		resp, err := client.ExternalClusterAPIListClustersWithResponse(ctx)
+		if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+			return nil, fmt.Errorf("listing clusters: %w", checkErr)
+		}
+
+		for _, cluster := range lo.FromPtr(resp.JSON200.Items) {
+			if len(providerTypes) > 0 && !lo.Contains(providerTypes, lo.FromPtr(cluster.ProviderType)) {
+				continue
+			}
+			if len(regions) > 0 && (cluster.Region == nil || !lo.Contains(regions, lo.FromPtr(cluster.Region.Name))) {
+				continue
+			}
+			if nameRegex != nil && !nameRegex.MatchString(lo.FromPtr(cluster.Name)) {
+				continue
+			}
+			selected = append(selected, lo.FromPtr(cluster.Id))
+		}
+	}
+
+	selected = lo.Uniq(selected)
+	sort.Strings(selected)
+	return selected, nil
+}
+
+func setRebalancingJobsState(d *schema.ResourceData, jobIDs map[string]string) error {
+	if err := d.Set(FieldRebalancingJobsJobIDs, jobIDs); err != nil {
+		return fmt.Errorf("setting job ids: %w", err)
+	}
+	if err := d.Set(FieldRebalancingJobsMatchedClusterIDs, lo.Keys(jobIDs)); err != nil {
+		return fmt.Errorf("setting matched cluster ids: %w", err)
+	}
+	return nil
+}
+
+func rebalancingJobsClusterDiagnostic(severity diag.Severity, clusterID, action string, err error) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: severity,
+		Summary:  fmt.Sprintf("%s for cluster %s", action, clusterID),
+		Detail:   err.Error(),
+	}
+}
@@ -0,0 +1,286 @@
+package castai
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+const rebalancingJobsClustersBody = `
+{
+  "items": [
+	{"id": "11111111-1111-4111-8111-111111111111", "name": "prod-eu", "providerType": "eks", "region": {"name": "eu-central-1"}},
+	{"id": "22222222-2222-4222-8222-222222222222", "name": "prod-us", "providerType": "eks", "region": {"name": "us-east-1"}},
+	{"id": "33333333-3333-4333-8333-333333333333", "name": "staging-eu", "providerType": "gke", "region": {"name": "europe-west1"}},
+	{"id": "44444444-4444-4444-8444-444444444444", "name": "prod-aks", "providerType": "aks", "region": {"name": "westeurope"}}
+  ]
+}`
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewReader([]byte(body))), Header: map[string][]string{"Content-Type": {"json"}}}
+}
+
+func TestSelectRebalancingJobsClusters(t *testing.T) {
+	tt := map[string]struct {
+		selector map[string]any
+		expected []string
+	}{
+		"provider type": {
+			selector: map[string]any{FieldRebalancingJobsProviderTypes: []any{"eks"}},
+			expected: []string{"11111111-1111-4111-8111-111111111111", "22222222-2222-4222-8222-222222222222"},
+		},
+		"all filters have to match": {
+			selector: map[string]any{
+				FieldRebalancingJobsProviderTypes: []any{"eks", "gke"},
+				FieldRebalancingJobsRegions:       []any{"eu-central-1", "europe-west1"},
+				FieldRebalancingJobsNameRegex:     "^prod-",
+			},
+			expected: []string{"11111111-1111-4111-8111-111111111111"},
+		},
+		"explicit ids are added to filtered ones": {
+			selector: map[string]any{
+				FieldRebalancingJobsClusterIDs: []any{"44444444-4444-4444-8444-444444444444", "11111111-1111-4111-8111-111111111111"},
+				FieldRebalancingJobsNameRegex:  "-eu$",
+			},
+			expected: []string{"11111111-1111-4111-8111-111111111111", "33333333-3333-4333-8333-333333333333", "44444444-4444-4444-8444-444444444444"},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+			client := &sdk.ClientWithResponses{ClientInterface: mockClient}
+			mockClient.EXPECT().ExternalClusterAPIListClusters(gomock.Any()).Return(jsonResponse(200, rebalancingJobsClustersBody), nil)
+
+			d := schema.TestResourceDataRaw(t, resourceRebalancingJobs().Schema, map[string]any{
+				FieldRebalancingJobsScheduleID:      "9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c",
+				FieldRebalancingJobsClusterSelector: []any{tc.selector},
+			})
+
+			selected, err := selectRebalancingJobsClusters(context.Background(), client, d.Get(FieldRebalancingJobsClusterSelector).([]any))
+			r.NoError(err)
+			r.Equal(tc.expected, selected)
+		})
+	}
+}
+
+func TestSelectRebalancingJobsClusters_explicitIDsOnly(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	client := &sdk.ClientWithResponses{ClientInterface: mockClient}
+
+	d := schema.TestResourceDataRaw(t, resourceRebalancingJobs().Schema, map[string]any{
+		FieldRebalancingJobsScheduleID: "9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c",
+		FieldRebalancingJobsClusterSelector: []any{map[string]any{
+			FieldRebalancingJobsClusterIDs: []any{"22222222-2222-4222-8222-222222222222"},
+		}},
+	})
+
+	selected, err := selectRebalancingJobsClusters(context.Background(), client, d.Get(FieldRebalancingJobsClusterSelector).([]any))
+	r.NoError(err)
+	r.Equal([]string{"22222222-2222-4222-8222-222222222222"}, selected)
+}
+
+func TestRebalancingJobsResourceReconcile(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{api: &sdk.ClientWithResponses{ClientInterface: mockClient}}
+
+	scheduleID := "9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c"
+	euCluster := "11111111-1111-4111-8111-111111111111"
+	usCluster := "22222222-2222-4222-8222-222222222222"
+	foreignCluster := "33333333-3333-4333-8333-333333333333"
+	removedCluster := "44444444-4444-4444-8444-444444444444"
+
+	mockClient.EXPECT().ExternalClusterAPIListClusters(gomock.Any()).Return(jsonResponse(200, rebalancingJobsClustersBody), nil)
+
+	// Job created by the resource before is enabled back.
+	mockClient.EXPECT().ScheduledRebalancingAPIListRebalancingJobs(gomock.Any(), euCluster).Return(jsonResponse(200, `
+		{"jobs": [{"id": "job-eu", "clusterId": "11111111-1111-4111-8111-111111111111", "rebalancingScheduleId": "9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c", "enabled": false}]}
+	`), nil)
+	mockClient.EXPECT().
+		ScheduledRebalancingAPIUpdateRebalancingJob(gomock.Any(), euCluster, "job-eu", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, req sdk.ScheduledRebalancingAPIUpdateRebalancingJobJSONRequestBody) (*http.Response, error) {
+			r.True(*req.Enabled)
+			return jsonResponse(200, `{"id": "job-eu", "enabled": true}`), nil
+		})
+
+	// Creating job in the other cluster fails, which must not stop reconciliation.
+	mockClient.EXPECT().ScheduledRebalancingAPIListRebalancingJobs(gomock.Any(), usCluster).Return(jsonResponse(200, `{"jobs": []}`), nil)
+	mockClient.EXPECT().
+		ScheduledRebalancingAPICreateRebalancingJob(gomock.Any(), usCluster, gomock.Any()).
+		Return(jsonResponse(500, `{"message": "internal error"}`), nil)
+
+	// Job of the schedule created by other means is not adopted.
+	mockClient.EXPECT().ScheduledRebalancingAPIListRebalancingJobs(gomock.Any(), foreignCluster).Return(jsonResponse(200, `
+		{"jobs": [{"id": "job-foreign", "clusterId": "33333333-3333-4333-8333-333333333333", "rebalancingScheduleId": "9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c", "enabled": true}]}
+	`), nil)
+
+	// Cluster which is not selected anymore gets its job deleted.
+	mockClient.EXPECT().ScheduledRebalancingAPIDeleteRebalancingJob(gomock.Any(), removedCluster, "job-removed").Return(jsonResponse(200, `{}`), nil)
+
+	d := schema.TestResourceDataRaw(t, resourceRebalancingJobs().Schema, map[string]any{
+		FieldRebalancingJobsScheduleID: scheduleID,
+		FieldRebalancingJobsClusterSelector: []any{map[string]any{
+			FieldRebalancingJobsClusterIDs:    []any{foreignCluster},
+			FieldRebalancingJobsProviderTypes: []any{"eks"},
+		}},
+	})
+	d.SetId(scheduleID)
+	r.NoError(d.Set(FieldRebalancingJobsJobIDs, map[string]string{euCluster: "job-eu", removedCluster: "job-removed"}))
+
+	diags := resourceRebalancingJobsUpdate(context.Background(), d, provider)
+	r.Len(diags, 2)
+	summaries := map[string]diag.Diagnostic{}
+	for _, diagnostic := range diags {
+		summaries[diagnostic.Summary] = diagnostic
+	}
+	r.Equal(diag.Warning, summaries["assigning rebalancing schedule for cluster "+usCluster].Severity)
+	foreign := summaries["assigning rebalancing schedule for cluster "+foreignCluster]
+	r.Equal(diag.Warning, foreign.Severity)
+	r.Contains(foreign.Detail, "job-foreign of the schedule which is not managed by this resource")
+
+	r.Equal(map[string]any{euCluster: "job-eu"}, d.Get(FieldRebalancingJobsJobIDs))
+	r.Equal([]any{euCluster}, d.Get(FieldRebalancingJobsMatchedClusterIDs).(*schema.Set).List())
+}
+
+func TestRebalancingJobsResourceCreate_clusterFailure(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{api: &sdk.ClientWithResponses{ClientInterface: mockClient}}
+
+	scheduleID := "9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c"
+	euCluster := "11111111-1111-4111-8111-111111111111"
+	usCluster := "22222222-2222-4222-8222-222222222222"
+
+	mockClient.EXPECT().ScheduledRebalancingAPIListRebalancingJobs(gomock.Any(), euCluster).Return(jsonResponse(200, `{"jobs": []}`), nil)
+	mockClient.EXPECT().
+		ScheduledRebalancingAPICreateRebalancingJob(gomock.Any(), euCluster, gomock.Any()).
+		Return(jsonResponse(200, `{"id": "job-eu", "enabled": true}`), nil)
+	mockClient.EXPECT().ScheduledRebalancingAPIListRebalancingJobs(gomock.Any(), usCluster).Return(jsonResponse(500, `{}`), nil)
+
+	d := schema.TestResourceDataRaw(t, resourceRebalancingJobs().Schema, map[string]any{
+		FieldRebalancingJobsScheduleID: scheduleID,
+		FieldRebalancingJobsClusterSelector: []any{map[string]any{
+			FieldRebalancingJobsClusterIDs: []any{euCluster, usCluster},
+		}},
+	})
+
+	diags := resourceRebalancingJobsCreate(context.Background(), d, provider)
+	r.Len(diags, 1)
+	r.Equal(diag.Warning, diags[0].Severity)
+	r.Equal("assigning rebalancing schedule for cluster "+usCluster, diags[0].Summary)
+
+	r.NotEmpty(d.Id())
+	r.NotEqual(scheduleID, d.Id())
+	r.Equal(map[string]any{euCluster: "job-eu"}, d.Get(FieldRebalancingJobsJobIDs))
+	r.Equal([]any{euCluster}, d.Get(FieldRebalancingJobsMatchedClusterIDs).(*schema.Set).List())
+}
+
+func TestRebalancingJobsResourceRead(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{api: &sdk.ClientWithResponses{ClientInterface: mockClient}}
+
+	scheduleID := "9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c"
+	euCluster := "11111111-1111-4111-8111-111111111111"
+	usCluster := "22222222-2222-4222-8222-222222222222"
+	deletedCluster := "33333333-3333-4333-8333-333333333333"
+	failingCluster := "44444444-4444-4444-8444-444444444444"
+
+	mockClient.EXPECT().ScheduledRebalancingAPIListRebalancingJobs(gomock.Any(), euCluster).Return(jsonResponse(200, `
+		{"jobs": [{"id": "job-eu", "rebalancingScheduleId": "9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c", "enabled": true}]}
+	`), nil)
+	// Job replaced by another one of the schedule is not managed by the resource anymore.
+	mockClient.EXPECT().ScheduledRebalancingAPIListRebalancingJobs(gomock.Any(), usCluster).Return(jsonResponse(200, `
+		{"jobs": [{"id": "job-other", "rebalancingScheduleId": "9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c", "enabled": true}]}
+	`), nil)
+	mockClient.EXPECT().ScheduledRebalancingAPIListRebalancingJobs(gomock.Any(), deletedCluster).Return(jsonResponse(404, `{}`), nil)
+	mockClient.EXPECT().ScheduledRebalancingAPIListRebalancingJobs(gomock.Any(), failingCluster).Return(jsonResponse(500, `{}`), nil)
+
+	d := schema.TestResourceDataRaw(t, resourceRebalancingJobs().Schema, map[string]any{
+		FieldRebalancingJobsScheduleID: scheduleID,
+	})
+	d.SetId(scheduleID)
+	r.NoError(d.Set(FieldRebalancingJobsJobIDs, map[string]string{
+		euCluster:      "job-eu",
+		usCluster:      "job-us",
+		deletedCluster: "job-deleted",
+		failingCluster: "job-failing",
+	}))
+
+	diags := resourceRebalancingJobsRead(context.Background(), d, provider)
+	r.Len(diags, 1)
+	r.Equal("reading rebalancing job for cluster "+failingCluster, diags[0].Summary)
+	r.Equal(map[string]any{euCluster: "job-eu", failingCluster: "job-failing"}, d.Get(FieldRebalancingJobsJobIDs))
+	r.True(d.Get(FieldRebalancingJobsEnabled).(bool))
+	r.Empty(d.Get(FieldRebalancingJobsOutOfSyncIDs).(*schema.Set).List())
+}
+
+func TestRebalancingJobsResourceRead_enabledDrift(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{api: &sdk.ClientWithResponses{ClientInterface: mockClient}}
+
+	scheduleID := "9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c"
+	euCluster := "11111111-1111-4111-8111-111111111111"
+	usCluster := "22222222-2222-4222-8222-222222222222"
+
+	mockClient.EXPECT().ScheduledRebalancingAPIListRebalancingJobs(gomock.Any(), euCluster).Return(jsonResponse(200, `
+		{"jobs": [{"id": "job-eu", "rebalancingScheduleId": "9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c", "enabled": true}]}
+	`), nil)
+	mockClient.EXPECT().ScheduledRebalancingAPIListRebalancingJobs(gomock.Any(), usCluster).Return(jsonResponse(200, `
+		{"jobs": [{"id": "job-us", "rebalancingScheduleId": "9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c", "enabled": false}]}
+	`), nil)
+
+	d := schema.TestResourceDataRaw(t, resourceRebalancingJobs().Schema, map[string]any{
+		FieldRebalancingJobsScheduleID: scheduleID,
+		FieldRebalancingJobsEnabled:    true,
+	})
+	d.SetId(scheduleID)
+	r.NoError(d.Set(FieldRebalancingJobsJobIDs, map[string]string{euCluster: "job-eu", usCluster: "job-us"}))
+
+	diags := resourceRebalancingJobsRead(context.Background(), d, provider)
+	r.Empty(diags)
+	r.True(d.Get(FieldRebalancingJobsEnabled).(bool))
+	r.Equal([]any{usCluster}, d.Get(FieldRebalancingJobsOutOfSyncIDs).(*schema.Set).List())
+}
+
+func TestRebalancingJobsResourceDiff_outOfSync(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{api: &sdk.ClientWithResponses{ClientInterface: mockClient}}
+
+	euCluster := "11111111-1111-4111-8111-111111111111"
+
+	resource := resourceRebalancingJobs()
+	raw := map[string]any{
+		FieldRebalancingJobsScheduleID: "9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c",
+		FieldRebalancingJobsEnabled:    true,
+		FieldRebalancingJobsClusterSelector: []any{map[string]any{
+			FieldRebalancingJobsClusterIDs: []any{euCluster},
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, resource.Schema, raw)
+	d.SetId("0b6f3c8e-6a1d-4f2b-9c5e-7d8a9b0c1d2e")
+	r.NoError(setRebalancingJobsState(d, map[string]string{euCluster: "job-eu"}))
+	r.NoError(d.Set(FieldRebalancingJobsOutOfSyncIDs, []string{euCluster}))
+
+	diff, err := resource.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), provider)
+	r.NoError(err)
+	r.NotNil(diff)
+	r.Equal("0", diff.Attributes[FieldRebalancingJobsOutOfSyncIDs+".#"].New)
+	r.NotContains(diff.Attributes, FieldRebalancingJobsMatchedClusterIDs+".#")
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"reflect"
+	"sort"
 	"time"
 
 	"github.com/castai/terraform-provider-castai/castai/sdk"
@@ -22,6 +24,7 @@ func resourceRebalancingSchedule() *schema.Resource {
 		ReadContext:   resourceRebalancingScheduleRead,
 		DeleteContext: resourceRebalancingScheduleDelete,
 		UpdateContext: resourceRebalancingScheduleUpdate,
+		CustomizeDiff: rebalancingScheduleDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: rebalancingScheduleStateImporter,
 		},
@@ -48,23 +51,69 @@ func resourceRebalancingSchedule() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"cron": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ExactlyOneOf:     []string{"schedule.0.cron", "schedule.0." + FieldRebalancingScheduleAt},
+							ValidateDiagFunc: validateCron(),
+							Description: "Cron expression defining when the schedule should trigger.\n\n" +
+								"  The `cron` expression can optionally include the `CRON_TZ` variable at the beginning to specify the timezone in which the schedule should be interpreted.\n\n" +
+								"  Example:\n" +
+								"  ```plaintext\n" +
+								"  CRON_TZ=America/New_York 0 12 * * ?\n" +
+								"  ```\n" +
+								"  In the example above, the `CRON_TZ` variable is set to \"America/New_York\" indicating that the cron expression should be interpreted in the Eastern Time (ET) timezone.\n\n" +
+								"  Available timezone values can be retrieved using `castai_rebalancing_time_zones` data source. " +
+								"The cron expression and its timezone are validated at plan time.\n\n" +
+								"  If the `CRON_TZ` variable is not specified, the cron expression will be interpreted in the UTC timezone.",
+						},
+						FieldRebalancingScheduleAt: {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ExactlyOneOf:     []string{"schedule.0.cron", "schedule.0." + FieldRebalancingScheduleAt},
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+							Description: "Time in RFC 3339 format to trigger the schedule at once, e.g. `2025-12-01T02:00:00Z`. Has to be in the future when set. " +
+								"Neither the API nor the provider enforce a single trigger: the schedule is created with a cron expression triggering at the same " +
+								"time every year and only `suspended` turns true after the trigger. Jobs keep triggering yearly unless they are disabled " +
+								"with `enabled = !castai_rebalancing_schedule.<name>.suspended` and terraform is applied after the trigger.",
+						},
+					},
+				},
+			},
+			FieldRebalancingScheduleBlackoutWindows: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start": {
 							Type:             schema.TypeString,
 							Required:         true,
-							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
-							Description:      "Cron expression defining when the schedule should trigger.\n\n"+
-											"  The `cron` expression can optionally include the `CRON_TZ` variable at the beginning to specify the timezone in which the schedule should be interpreted.\n\n"+
-											"  Example:\n"+
-											"  ```plaintext\n"+
-											"  CRON_TZ=America/New_York 0 12 * * ?\n"+
-											"  ```\n"+
-											"  In the example above, the `CRON_TZ` variable is set to \"America/New_York\" indicating that the cron expression should be interpreted in the Eastern Time (ET) timezone.\n\n"+
-											"  To retrieve a list of available timezone values, you can use the following API endpoint:\n\n"+
-											"  GET https://api.cast.ai/v1/time-zones\n\n"+
-											"  When using the `CRON_TZ` variable, ensure that the specified timezone is valid and supported by checking the list of available timezones from the API endpoint."+
-											"  If the `CRON_TZ` variable is not specified, the cron expression will be interpreted in the UTC timezone.",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+							Description:      "Start of the window in RFC 3339 format.",
+						},
+						"end": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+							Description:      "End of the window in RFC 3339 format.",
+						},
+						"weekly": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Repeat the window every week from its start onwards. Window has to be shorter than a week.",
 						},
 					},
 				},
+				Description: "Time windows during which the schedule must not trigger rebalancing. " +
+					"Windows aren't enforced by the API or the provider, they only turn `suspended` true while active. Jobs of the schedule are " +
+					"disabled only when they use `enabled = !castai_rebalancing_schedule.<name>.suspended` and terraform is applied while a window " +
+					"is active, and enabled back by an apply after it ends, e.g. on a schedule in CI.",
+			},
+			FieldRebalancingScheduleSuspended: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: "Whether jobs of the schedule have to be disabled because of an active blackout window or a passed one-shot trigger. " +
+					"Refreshed on every read. The schedule doesn't disable its jobs, set `enabled = !castai_rebalancing_schedule.<name>.suspended` of the jobs instead.",
 			},
 			"trigger_conditions": {
 				Type:     schema.TypeList,
@@ -135,8 +184,20 @@ func resourceRebalancingSchedule() *schema.Resource {
 						"selector": {
 							Type:             schema.TypeString,
 							Optional:         true,
-							Description:      "Node selector in JSON format.",
+							Description:      "Node selector in JSON format. Consider using `node_selector` block instead.",
 							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+							DiffSuppressFunc: suppressEquivalentJSONDiffs,
+							ConflictsWith:    []string{"launch_configuration.0.node_selector"},
+						},
+						FieldNodeSelector: {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: labelSelectorFields(nodeSelectorOperators),
+							},
+							ConflictsWith: []string{"launch_configuration.0.selector"},
+							Description:   "Node selector of nodes to be considered for rebalancing. All match labels and match expressions have to be satisfied.",
 						},
 					},
 				},
@@ -145,6 +206,23 @@ func resourceRebalancingSchedule() *schema.Resource {
 	}
 }
 
+func rebalancingScheduleDiff(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	if err := rebalancingScheduleWindowsDiff(d, time.Now()); err != nil {
+		return err
+	}
+
+	if nodeSelectors, _ := d.Get("launch_configuration.0.node_selector").([]any); len(nodeSelectors) > 0 {
+		if err := validateLabelSelector(d, "launch_configuration.0.node_selector.0", nodeSelectorOperators); err != nil {
+			return err
+		}
+	}
+
+	if !d.NewValueKnown("schedule.0.cron") || d.Get("schedule.0.cron").(string) == "" {
+		return nil
+	}
+	return validateRebalancingTimeZone(ctx, meta.(*ProviderConfig).api, d.Get("schedule.0.cron").(string))
+}
+
 func resourceRebalancingScheduleCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	client := meta.(*ProviderConfig).api
 
@@ -184,6 +262,13 @@ func resourceRebalancingScheduleRead(ctx context.Context, d *schema.ResourceData
 	if err := scheduleToState(schedule, d); err != nil {
 		return diag.FromErr(err)
 	}
+	suspended, err := rebalancingScheduleSuspended(d, lo.FromPtr(schedule.Jobs), time.Now())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(FieldRebalancingScheduleSuspended, suspended); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
@@ -237,13 +322,16 @@ func rebalancingScheduleStateImporter(ctx context.Context, d *schema.ResourceDat
 }
 
 func stateToSchedule(d *schema.ResourceData) (*sdk.ScheduledrebalancingV1RebalancingSchedule, error) {
-	scheduleData := toSection(d, "schedule")
+	cron, err := scheduleCron(toSection(d, "schedule"))
+	if err != nil {
+		return nil, err
+	}
 
 	result := sdk.ScheduledrebalancingV1RebalancingSchedule{
 		Id:   lo.ToPtr(d.Id()),
 		Name: d.Get("name").(string),
 		Schedule: sdk.ScheduledrebalancingV1Schedule{
-			Cron: scheduleData["cron"].(string),
+			Cron: cron,
 		},
 	}
 	if triggerConditions := toSection(d, "trigger_conditions"); triggerConditions != nil {
@@ -253,35 +341,46 @@ func stateToSchedule(d *schema.ResourceData) (*sdk.ScheduledrebalancingV1Rebalan
 	}
 
 	if launchConfigurationData := toSection(d, "launch_configuration"); launchConfigurationData != nil {
-		selector, err := readOptionalJson[sdk.ScheduledrebalancingV1NodeSelector](launchConfigurationData, "selector")
+		launchConfiguration, err := toLaunchConfiguration(launchConfigurationData)
 		if err != nil {
-			return nil, fmt.Errorf("parsing selector: %w", err)
+			return nil, err
 		}
+		result.LaunchConfiguration = *launchConfiguration
+	}
 
-		keepDrainTimeoutNodes := readOptionalValue[bool](launchConfigurationData, "keep_drain_timeout_nodes")
+	return &result, nil
+}
 
-		var executionConditions *sdk.ScheduledrebalancingV1ExecutionConditions
-		executionConditionsData := launchConfigurationData["execution_conditions"].([]any)
-		if len(executionConditionsData) != 0 {
-			executionConditions = &sdk.ScheduledrebalancingV1ExecutionConditions{
-				Enabled:                   lo.ToPtr(executionConditionsData[0].(map[string]any)["enabled"].(bool)),
-				AchievedSavingsPercentage: lo.ToPtr(int32(executionConditionsData[0].(map[string]any)["achieved_savings_percentage"].(int))),
-			}
-		}
+func toLaunchConfiguration(launchConfigurationData map[string]any) (*sdk.ScheduledrebalancingV1LaunchConfiguration, error) {
+	selector, err := readOptionalJson[sdk.ScheduledrebalancingV1NodeSelector](launchConfigurationData, "selector")
+	if err != nil {
+		return nil, fmt.Errorf("parsing selector: %w", err)
+	}
+	if nodeSelectors, ok := launchConfigurationData[FieldNodeSelector].([]any); ok && len(nodeSelectors) > 0 && nodeSelectors[0] != nil {
+		selector = toRebalancingNodeSelector(nodeSelectors[0].(map[string]any))
+	}
 
-		result.LaunchConfiguration = sdk.ScheduledrebalancingV1LaunchConfiguration{
-			NodeTtlSeconds:   readOptionalNumber[int, int32](launchConfigurationData, "node_ttl_seconds"),
-			NumTargetedNodes: readOptionalNumber[int, int32](launchConfigurationData, "num_targeted_nodes"),
-			RebalancingOptions: &sdk.ScheduledrebalancingV1RebalancingOptions{
-				MinNodes:              readOptionalNumber[int, int32](launchConfigurationData, "rebalancing_min_nodes"),
-				KeepDrainTimeoutNodes: keepDrainTimeoutNodes,
-				ExecutionConditions:   executionConditions,
-			},
-			Selector: selector,
+	keepDrainTimeoutNodes := readOptionalValue[bool](launchConfigurationData, "keep_drain_timeout_nodes")
+
+	var executionConditions *sdk.ScheduledrebalancingV1ExecutionConditions
+	executionConditionsData := launchConfigurationData["execution_conditions"].([]any)
+	if len(executionConditionsData) != 0 {
+		executionConditions = &sdk.ScheduledrebalancingV1ExecutionConditions{
+			Enabled:                   lo.ToPtr(executionConditionsData[0].(map[string]any)["enabled"].(bool)),
+			AchievedSavingsPercentage: lo.ToPtr(int32(executionConditionsData[0].(map[string]any)["achieved_savings_percentage"].(int))),
 		}
 	}
 
-	return &result, nil
+	return &sdk.ScheduledrebalancingV1LaunchConfiguration{
+		NodeTtlSeconds:   readOptionalNumber[int, int32](launchConfigurationData, "node_ttl_seconds"),
+		NumTargetedNodes: readOptionalNumber[int, int32](launchConfigurationData, "num_targeted_nodes"),
+		RebalancingOptions: &sdk.ScheduledrebalancingV1RebalancingOptions{
+			MinNodes:              readOptionalNumber[int, int32](launchConfigurationData, "rebalancing_min_nodes"),
+			KeepDrainTimeoutNodes: keepDrainTimeoutNodes,
+			ExecutionConditions:   executionConditions,
+		},
+		Selector: selector,
+	}, nil
 }
 
 func scheduleToState(schedule *sdk.ScheduledrebalancingV1RebalancingSchedule, d *schema.ResourceData) error {
@@ -289,11 +388,20 @@ func scheduleToState(schedule *sdk.ScheduledrebalancingV1RebalancingSchedule, d
 	if err := d.Set("name", schedule.Name); err != nil {
 		return err
 	}
-	if err := d.Set("schedule", []map[string]any{
-		{
-			"cron": schedule.Schedule.Cron,
-		},
-	}); err != nil {
+	// One-shot trigger time is kept as long as the schedule still triggers at it.
+	scheduleState := map[string]any{
+		"cron":                     schedule.Schedule.Cron,
+		FieldRebalancingScheduleAt: "",
+	}
+	if at := d.Get("schedule.0." + FieldRebalancingScheduleAt).(string); at != "" {
+		if cron, err := scheduleCron(map[string]any{FieldRebalancingScheduleAt: at}); err == nil && cron == schedule.Schedule.Cron {
+			scheduleState = map[string]any{
+				"cron":                     "",
+				FieldRebalancingScheduleAt: at,
+			}
+		}
+	}
+	if err := d.Set("schedule", []map[string]any{scheduleState}); err != nil {
 		return err
 	}
 
@@ -320,11 +428,19 @@ func scheduleToState(schedule *sdk.ScheduledrebalancingV1RebalancingSchedule, d
 	selector := schedule.LaunchConfiguration.Selector
 	if selector != nil && selector.NodeSelectorTerms != nil && len(*selector.NodeSelectorTerms) > 0 {
 		nullifySelectorEmptyLists(selector)
-		selectorJSON, err := json.Marshal(selector)
-		if err != nil {
-			return fmt.Errorf("serializing selector: %w", err)
+		currentNodeSelectors, _ := d.Get("launch_configuration.0.node_selector").([]any)
+		if nodeSelector := flattenRebalancingNodeSelector(selector, currentNodeSelectors); len(currentNodeSelectors) > 0 && nodeSelector != nil {
+			launchConfig[FieldNodeSelector] = nodeSelector
+		} else {
+			selectorJSON, err := json.Marshal(selector)
+			if err != nil {
+				return fmt.Errorf("serializing selector: %w", err)
+			}
+			if selectorJSON, err = normalizeJSON(selectorJSON); err != nil {
+				return fmt.Errorf("normalizing selector: %w", err)
+			}
+			launchConfig["selector"] = string(selectorJSON)
 		}
-		launchConfig["selector"] = string(selectorJSON)
 	}
 
 	if err := d.Set("launch_configuration", []map[string]any{launchConfig}); err != nil {
@@ -377,6 +493,73 @@ func nullifySelectorRequirements(requirements *[]sdk.ScheduledrebalancingV1NodeS
 	}
 }
 
+// toRebalancingNodeSelector converts node_selector block to a single node selector term: match labels become
+// In requirements with a single value, ordered by key, followed by match expressions.
+func toRebalancingNodeSelector(nodeSelectorData map[string]any) *sdk.ScheduledrebalancingV1NodeSelector {
+	var requirements []sdk.ScheduledrebalancingV1NodeSelectorRequirement
+
+	labels, _ := nodeSelectorData[FieldMatchLabels].(map[string]any)
+	keys := lo.Keys(labels)
+	sort.Strings(keys)
+	for _, key := range keys {
+		requirements = append(requirements, sdk.ScheduledrebalancingV1NodeSelectorRequirement{
+			Key:      lo.ToPtr(key),
+			Operator: lo.ToPtr(labelSelectorOpIn),
+			Values:   &[]string{labels[key].(string)},
+		})
+	}
+
+	expressions, _ := nodeSelectorData[FieldMatchExpressions].([]any)
+	for _, e := range expressions {
+		expression := e.(map[string]any)
+		values, _ := expression[FieldMatchExpressionVal].([]any)
+		requirements = append(requirements, sdk.ScheduledrebalancingV1NodeSelectorRequirement{
+			Key:      lo.ToPtr(expression[FieldMatchExpressionKey].(string)),
+			Operator: lo.ToPtr(expression[FieldMatchExpressionOp].(string)),
+			Values:   toNilList(lo.ToPtr(toStringList(values))),
+		})
+	}
+
+	return &sdk.ScheduledrebalancingV1NodeSelector{
+		NodeSelectorTerms: &[]sdk.ScheduledrebalancingV1NodeSelectorTerm{
+			{MatchExpressions: toNilList(&requirements)},
+		},
+	}
+}
+
+// flattenRebalancingNodeSelector returns node_selector block for the selector, or nil when it cannot be expressed as one,
+// i.e. it has several terms or match fields. When current block is equivalent to the selector, it is kept as is,
+// as labels and single value In expressions cannot be told apart.
+func flattenRebalancingNodeSelector(selector *sdk.ScheduledrebalancingV1NodeSelector, current []any) []any {
+	if selector.NodeSelectorTerms == nil || len(*selector.NodeSelectorTerms) != 1 {
+		return nil
+	}
+	term := (*selector.NodeSelectorTerms)[0]
+	if term.MatchFields != nil && len(*term.MatchFields) > 0 {
+		return nil
+	}
+
+	if len(current) > 0 && current[0] != nil {
+		currentSelector := toRebalancingNodeSelector(current[0].(map[string]any))
+		nullifySelectorEmptyLists(currentSelector)
+		if reflect.DeepEqual(currentSelector, selector) {
+			return current
+		}
+	}
+
+	expressions := lo.Map(lo.FromPtr(term.MatchExpressions), func(r sdk.ScheduledrebalancingV1NodeSelectorRequirement, _ int) map[string]any {
+		return map[string]any{
+			FieldMatchExpressionKey: lo.FromPtr(r.Key),
+			FieldMatchExpressionOp:  lo.FromPtr(r.Operator),
+			FieldMatchExpressionVal: lo.FromPtr(r.Values),
+		}
+	})
+	return []any{map[string]any{
+		FieldMatchLabels:      map[string]any{},
+		FieldMatchExpressions: expressions,
+	}}
+}
+
 func getRebalancingScheduleByName(ctx context.Context, client *sdk.ClientWithResponses, name string) (*sdk.ScheduledrebalancingV1RebalancingSchedule, error) {
 	resp, err := client.ScheduledRebalancingAPIListRebalancingSchedulesWithResponse(ctx)
 	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
@@ -1,11 +1,26 @@
 package castai
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
 )
 
 func TestAccResourceRebalancingSchedule_basic(t *testing.T) {
@@ -133,3 +148,323 @@ resource "castai_rebalancing_schedule" "test" {
 `
 	return fmt.Sprintf(template, rName)
 }
+
+func TestRebalancingScheduleResourceCustomizeDiff_timeZone(t *testing.T) {
+	tt := map[string]struct {
+		cron      string
+		listCalls int
+		errMsg    string
+	}{
+		"utc": {
+			cron: "0 12 * * ?",
+		},
+		"supported time zone": {
+			cron:      "CRON_TZ=America/New_York 0 12 * * ?",
+			listCalls: 1,
+		},
+		"unsupported time zone": {
+			cron:      "CRON_TZ=Antarctica/Troll 0 12 * * ?",
+			listCalls: 1,
+			errMsg:    `time zone "Antarctica/Troll" of cron expression "CRON_TZ=Antarctica/Troll 0 12 * * ?" is not supported by rebalancing schedules`,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+			provider := &ProviderConfig{
+				api: &sdk.ClientWithResponses{
+					ClientInterface: mockClient,
+				},
+			}
+
+			mockClient.EXPECT().
+				ScheduledRebalancingAPIListAvailableRebalancingTZ(gomock.Any()).
+				DoAndReturn(func(_ context.Context) (*http.Response, error) {
+					return &http.Response{StatusCode: 200, Body: timeZonesBody(), Header: map[string][]string{"Content-Type": {"json"}}}, nil
+				}).MinTimes(tc.listCalls).MaxTimes(tc.listCalls * 2)
+
+			resource := resourceRebalancingSchedule()
+			val := cty.ObjectVal(map[string]cty.Value{
+				"name": cty.StringVal("spots"),
+				"schedule": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					"cron": cty.StringVal(tc.cron),
+				})}),
+				"trigger_conditions": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					"savings_percentage": cty.NumberIntVal(15),
+				})}),
+				"launch_configuration": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					"node_ttl_seconds": cty.NumberIntVal(3600),
+				})}),
+			})
+			state := &terraform.InstanceState{RawConfig: val}
+			config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+			_, err := resource.Diff(context.Background(), state, config, provider)
+			if tc.errMsg == "" {
+				r.NoError(err)
+				return
+			}
+			r.Error(err)
+			r.Contains(err.Error(), tc.errMsg)
+		})
+	}
+}
+
+func TestRebalancingScheduleResourceValidate_cron(t *testing.T) {
+	r := require.New(t)
+
+	diags := resourceRebalancingSchedule().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                 "spots",
+		"schedule":             []interface{}{map[string]interface{}{"cron": "0 12 * * 7"}},
+		"trigger_conditions":   []interface{}{map[string]interface{}{"savings_percentage": 15}},
+		"launch_configuration": []interface{}{map[string]interface{}{}},
+	}))
+	r.True(diags.HasError())
+	r.Equal(`"0 12 * * 7" is not a valid cron expression: end of range (7) above maximum (6): 7`, diags[0].Detail)
+}
+
+func TestRebalancingScheduleNodeSelector(t *testing.T) {
+	r := require.New(t)
+	resource := resourceRebalancingSchedule()
+
+	nodeSelector := map[string]interface{}{
+		FieldMatchLabels: map[string]interface{}{
+			"scheduling.cast.ai/spot": "true",
+		},
+		FieldMatchExpressions: []interface{}{
+			map[string]interface{}{"key": "topology.kubernetes.io/zone", "operator": "NotIn", "values": []interface{}{"eu-central-1a"}},
+			map[string]interface{}{"key": "scheduling.cast.ai/node-template", "operator": "DoesNotExist"},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"launch_configuration": []interface{}{map[string]interface{}{
+			FieldNodeSelector: []interface{}{nodeSelector},
+		}},
+	})
+	d.SetId("9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c")
+
+	launchConfiguration, err := toLaunchConfiguration(toSection(d, "launch_configuration"))
+	r.NoError(err)
+	selectorJSON, err := json.Marshal(launchConfiguration.Selector)
+	r.NoError(err)
+	r.JSONEq(`{"nodeSelectorTerms": [{"matchExpressions": [
+		{"key": "scheduling.cast.ai/spot", "operator": "In", "values": ["true"]},
+		{"key": "topology.kubernetes.io/zone", "operator": "NotIn", "values": ["eu-central-1a"]},
+		{"key": "scheduling.cast.ai/node-template", "operator": "DoesNotExist"}
+	]}]}`, string(selectorJSON))
+
+	// Equivalent selector keeps the configured block.
+	schedule := &sdk.ScheduledrebalancingV1RebalancingSchedule{
+		Id:                  lo.ToPtr(d.Id()),
+		Schedule:            sdk.ScheduledrebalancingV1Schedule{Cron: "0 12 * * *"},
+		LaunchConfiguration: *launchConfiguration,
+	}
+	r.NoError(scheduleToState(schedule, d))
+	r.Equal(map[string]interface{}{"scheduling.cast.ai/spot": "true"}, d.Get("launch_configuration.0.node_selector.0.match_labels"))
+	r.Equal(2, d.Get("launch_configuration.0.node_selector.0.match_expressions.#"))
+	r.Equal("", d.Get("launch_configuration.0.selector"))
+
+	// Selector changed outside of terraform is shown as match expressions.
+	(*(*schedule.LaunchConfiguration.Selector.NodeSelectorTerms)[0].MatchExpressions)[0].Values = &[]string{"false"}
+	r.NoError(scheduleToState(schedule, d))
+	r.Empty(d.Get("launch_configuration.0.node_selector.0.match_labels"))
+	r.Equal(3, d.Get("launch_configuration.0.node_selector.0.match_expressions.#"))
+	r.Equal("false", d.Get("launch_configuration.0.node_selector.0.match_expressions.0.values.0"))
+}
+
+func TestRebalancingScheduleSelectorJSONNormalized(t *testing.T) {
+	r := require.New(t)
+
+	d := schema.TestResourceDataRaw(t, resourceRebalancingSchedule().Schema, map[string]interface{}{})
+	schedule := &sdk.ScheduledrebalancingV1RebalancingSchedule{
+		Id:       lo.ToPtr("9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c"),
+		Schedule: sdk.ScheduledrebalancingV1Schedule{Cron: "0 12 * * *"},
+		LaunchConfiguration: sdk.ScheduledrebalancingV1LaunchConfiguration{
+			Selector: &sdk.ScheduledrebalancingV1NodeSelector{
+				NodeSelectorTerms: &[]sdk.ScheduledrebalancingV1NodeSelectorTerm{
+					{MatchExpressions: &[]sdk.ScheduledrebalancingV1NodeSelectorRequirement{
+						{Key: lo.ToPtr("scheduling.cast.ai/spot"), Operator: lo.ToPtr("Exists"), Values: &[]string{}},
+					}},
+				},
+			},
+		},
+	}
+
+	r.NoError(scheduleToState(schedule, d))
+	r.Equal(`{"nodeSelectorTerms":[{"matchExpressions":[{"key":"scheduling.cast.ai/spot","operator":"Exists"}]}]}`, d.Get("launch_configuration.0.selector"))
+	r.Equal(0, d.Get("launch_configuration.0.node_selector.#"))
+
+	suppress := resourceRebalancingSchedule().Schema["launch_configuration"].Elem.(*schema.Resource).Schema["selector"].DiffSuppressFunc
+	r.True(suppress("", d.Get("launch_configuration.0.selector").(string), `{
+		"nodeSelectorTerms": [{"matchExpressions": [{"operator": "Exists", "key": "scheduling.cast.ai/spot"}]}]
+	}`, nil))
+}
+
+func TestRebalancingScheduleResourceCustomizeDiff_nodeSelector(t *testing.T) {
+	r := require.New(t)
+
+	resource := resourceRebalancingSchedule()
+	val := cty.ObjectVal(map[string]cty.Value{
+		"name": cty.StringVal("spots"),
+		"schedule": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"cron": cty.StringVal("0 12 * * *"),
+		})}),
+		"trigger_conditions": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"savings_percentage": cty.NumberIntVal(15),
+		})}),
+		"launch_configuration": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			FieldNodeSelector: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				FieldMatchExpressions: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					"key":      cty.StringVal("castai.io/cpu"),
+					"operator": cty.StringVal("Gt"),
+					"values":   cty.ListVal([]cty.Value{cty.StringVal("four")}),
+				})}),
+			})}),
+		})}),
+	})
+	state := &terraform.InstanceState{RawConfig: val}
+	config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+	_, err := resource.Diff(context.Background(), state, config, &ProviderConfig{})
+	r.Error(err)
+	r.Contains(err.Error(), `launch_configuration.0.node_selector.0.match_expressions.0: operator Gt requires an integer value, got "four"`)
+}
+
+func TestRebalancingScheduleResourceValidate_selectorConflict(t *testing.T) {
+	r := require.New(t)
+
+	diags := resourceRebalancingSchedule().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":               "spots",
+		"schedule":           []interface{}{map[string]interface{}{"cron": "0 12 * * *"}},
+		"trigger_conditions": []interface{}{map[string]interface{}{"savings_percentage": 15}},
+		"launch_configuration": []interface{}{map[string]interface{}{
+			"selector": `{"nodeSelectorTerms": []}`,
+			FieldNodeSelector: []interface{}{map[string]interface{}{
+				FieldMatchLabels: map[string]interface{}{"scheduling.cast.ai/spot": "true"},
+			}},
+		}},
+	}))
+	r.True(diags.HasError())
+	r.True(lo.ContainsBy(diags, func(d diag.Diagnostic) bool {
+		return strings.Contains(d.Detail, `"launch_configuration.0.node_selector": conflicts with launch_configuration.0.selector`)
+	}), diags)
+}
+
+func TestRebalancingScheduleOneShot(t *testing.T) {
+	r := require.New(t)
+
+	d := schema.TestResourceDataRaw(t, resourceRebalancingSchedule().Schema, map[string]interface{}{
+		"name":     "after migration",
+		"schedule": []interface{}{map[string]interface{}{FieldRebalancingScheduleAt: "2025-12-01T02:00:00Z"}},
+	})
+	d.SetId("9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c")
+
+	schedule, err := stateToSchedule(d)
+	r.NoError(err)
+	r.Equal("0 2 1 12 *", schedule.Schedule.Cron)
+
+	r.NoError(scheduleToState(schedule, d))
+	r.Equal("2025-12-01T02:00:00Z", d.Get("schedule.0.at"))
+	r.Equal("", d.Get("schedule.0.cron"))
+
+	// Cron changed outside of terraform is shown as is.
+	schedule.Schedule.Cron = "0 3 1 12 *"
+	r.NoError(scheduleToState(schedule, d))
+	r.Equal("", d.Get("schedule.0.at"))
+	r.Equal("0 3 1 12 *", d.Get("schedule.0.cron"))
+}
+
+func TestRebalancingScheduleResourceCustomizeDiff_blackoutWindows(t *testing.T) {
+	r := require.New(t)
+
+	resource := resourceRebalancingSchedule()
+	val := cty.ObjectVal(map[string]cty.Value{
+		"name": cty.StringVal("spots"),
+		"schedule": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"cron": cty.StringVal("0 12 * * *"),
+		})}),
+		"trigger_conditions": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"savings_percentage": cty.NumberIntVal(15),
+		})}),
+		"launch_configuration": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"node_ttl_seconds": cty.NumberIntVal(3600),
+		})}),
+		FieldRebalancingScheduleBlackoutWindows: cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"start":  cty.StringVal("2024-11-29T00:00:00Z"),
+				"end":    cty.StringVal("2024-12-02T00:00:00Z"),
+				"weekly": cty.False,
+			}),
+			cty.ObjectVal(map[string]cty.Value{
+				"start":  cty.StringVal("2024-12-02T00:00:00Z"),
+				"end":    cty.StringVal("2024-11-29T00:00:00Z"),
+				"weekly": cty.False,
+			}),
+		}),
+	})
+	state := &terraform.InstanceState{RawConfig: val}
+	config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+	_, err := resource.Diff(context.Background(), state, config, &ProviderConfig{})
+	r.Error(err)
+	r.Contains(err.Error(), "blackout_windows.1: end 2024-11-29T00:00:00Z has to be after start 2024-12-02T00:00:00Z")
+}
+
+func TestRebalancingScheduleResourceCustomizeDiff_pastAt(t *testing.T) {
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	val := cty.ObjectVal(map[string]cty.Value{
+		"name": cty.StringVal("after migration"),
+		"schedule": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			FieldRebalancingScheduleAt: cty.StringVal(past),
+		})}),
+		"trigger_conditions": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"savings_percentage": cty.NumberIntVal(15),
+		})}),
+		"launch_configuration": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"node_ttl_seconds": cty.NumberIntVal(3600),
+		})}),
+	})
+
+	t.Run("rejected when set", func(t *testing.T) {
+		r := require.New(t)
+		resource := resourceRebalancingSchedule()
+		state := &terraform.InstanceState{RawConfig: val}
+		config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+		_, err := resource.Diff(context.Background(), state, config, &ProviderConfig{})
+		r.Error(err)
+		r.Contains(err.Error(), fmt.Sprintf("schedule.0.at: %s is in the past", past))
+	})
+
+	t.Run("accepted once triggered", func(t *testing.T) {
+		r := require.New(t)
+		resource := resourceRebalancingSchedule()
+		state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+		state.ID = "9f3e2d1c-0b4a-4e5f-8d7c-6b5a4f3e2d1c"
+		state.RawConfig = val
+		config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+		_, err := resource.Diff(context.Background(), state, config, &ProviderConfig{})
+		r.NoError(err)
+	})
+}
+
+func TestRebalancingScheduleResourceValidate_cronOrAt(t *testing.T) {
+	r := require.New(t)
+
+	diags := resourceRebalancingSchedule().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "spots",
+		"schedule": []interface{}{map[string]interface{}{
+			"cron":                     "0 12 * * *",
+			FieldRebalancingScheduleAt: "2025-12-01T02:00:00Z",
+		}},
+		"trigger_conditions":   []interface{}{map[string]interface{}{"savings_percentage": 15}},
+		"launch_configuration": []interface{}{map[string]interface{}{}},
+	}))
+	r.True(diags.HasError())
+	r.True(lo.ContainsBy(diags, func(d diag.Diagnostic) bool {
+		return strings.Contains(d.Detail, "only one of `schedule.0.at,schedule.0.cron` can be specified")
+	}), diags)
+}
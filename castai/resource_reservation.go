@@ -0,0 +1,208 @@
+package castai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+
+	"github.com/castai/terraform-provider-castai/castai/reservations"
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+)
+
+// reservationResourceFieldNames maps reservation fields to attributes of castai_reservation with different names.
+var reservationResourceFieldNames = map[string]string{
+	reservations.FieldReservationProvider: reservations.FieldReservationCloudProvider,
+	reservations.FieldReservationCount:    reservations.FieldReservationInstanceCount,
+}
+
+func reservationResourceFieldName(field string) string {
+	if name, found := reservationResourceFieldNames[field]; found {
+		return name
+	}
+	return field
+}
+
+func resourceReservation() *schema.Resource {
+	fields := lo.MapKeys(reservationFields(), func(field *schema.Schema, name string) string {
+		// Reservations can't be updated, changes are applied by replacing the reservation.
+		field.ForceNew = true
+		return reservationResourceFieldName(name)
+	})
+	fields[reservations.FieldReservationsOrganizationId] = &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		ForceNew:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IsUUID),
+		Description:      "organization, defaults to the only organization of the user",
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceReservationCreate,
+		ReadContext:   resourceReservationRead,
+		DeleteContext: resourceReservationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: reservationStateImporter,
+		},
+		Description: "Single cloud service provider reservation that can be used by CAST AI autoscaler. Unlike `castai_reservations`, " +
+			"it only manages the reservation it created, so reservations of an organization can be split between several configurations. " +
+			"It should not be combined with `castai_reservations` in the same organization, which overwrites all reservations.",
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+			Read:   schema.DefaultTimeout(2 * time.Minute),
+			Delete: schema.DefaultTimeout(2 * time.Minute),
+		},
+
+		Schema: fields,
+	}
+}
+
+func resourceReservationCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ProviderConfig).api
+
+	organizationId, err := getOrganizationId(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	reservationResource := reservations.MapReservationBlockToReservationResource(lo.MapValues(reservationFields(), func(_ *schema.Schema, field string) any {
+		return d.Get(reservationResourceFieldName(field))
+	}))
+	resp, err := client.InventoryAPIAddReservationWithResponse(ctx, organizationId, reservations.MapReservationResourceToGenericReservation(*reservationResource))
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return diag.Errorf("adding reservation: %v", checkErr)
+	}
+	if resp.JSON200.Reservation == nil || resp.JSON200.Reservation.ReservationId == nil {
+		return diag.Errorf("adding reservation: reservation id missing in response")
+	}
+
+	if err := d.Set(reservations.FieldReservationsOrganizationId, organizationId); err != nil {
+		return diag.FromErr(fmt.Errorf("setting organization id: %w", err))
+	}
+	d.SetId(*resp.JSON200.Reservation.ReservationId)
+
+	return resourceReservationRead(ctx, d, meta)
+}
+
+func resourceReservationRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ProviderConfig).api
+
+	organizationId, err := getOrganizationId(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := client.InventoryAPIGetReservationsWithResponse(ctx, organizationId)
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return diag.Errorf("fetching reservations: %v", checkErr)
+	}
+
+	reservation, found := lo.Find(lo.FromPtr(resp.JSON200.Reservations), func(item sdk.CastaiInventoryV1beta1ReservationDetails) bool {
+		return lo.FromPtr(item.ReservationId) == d.Id()
+	})
+	if !found {
+		if d.IsNewResource() {
+			return diag.Errorf("reservation %s not found after creation", d.Id())
+		}
+		tflog.Warn(ctx, "Reservation not found, removing from state", map[string]any{"id": d.Id()})
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set(reservations.FieldReservationsOrganizationId, organizationId); err != nil {
+		return diag.FromErr(fmt.Errorf("setting organization id: %w", err))
+	}
+	for field, value := range reservationDetailsToState(reservation) {
+		if err := d.Set(reservationResourceFieldName(field), value); err != nil {
+			return diag.FromErr(fmt.Errorf("setting %s: %w", field, err))
+		}
+	}
+
+	return nil
+}
+
+func resourceReservationDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ProviderConfig).api
+
+	organizationId, err := getOrganizationId(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := client.InventoryAPIDeleteReservationWithResponse(ctx, organizationId, d.Id())
+	if err == nil && resp.StatusCode() == http.StatusNotFound {
+		return nil
+	}
+	if checkErr := sdk.CheckOKResponse(resp, err); checkErr != nil {
+		return diag.Errorf("deleting reservation: %v", checkErr)
+	}
+
+	return nil
+}
+
+func reservationStateImporter(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	organizationId, reservationId, found := strings.Cut(d.Id(), "/")
+	if !found {
+		return nil, fmt.Errorf("expected import id in format <organization_id>/<reservation_id>, got %q", d.Id())
+	}
+	if _, err := uuid.Parse(organizationId); err != nil {
+		return nil, fmt.Errorf("parsing organization id: %w", err)
+	}
+
+	if err := d.Set(reservations.FieldReservationsOrganizationId, organizationId); err != nil {
+		return nil, err
+	}
+	d.SetId(reservationId)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func reservationDetailsToState(reservation sdk.CastaiInventoryV1beta1ReservationDetails) map[string]any {
+	var price float64
+	if reservation.Price != nil && *reservation.Price != "" {
+		price, _ = strconv.ParseFloat(*reservation.Price, 64)
+	}
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	return map[string]any{
+		reservations.FieldReservationName:         lo.FromPtr(reservation.Name),
+		reservations.FieldReservationProvider:     lo.FromPtr(reservation.Provider),
+		reservations.FieldReservationRegion:       lo.FromPtr(reservation.Region),
+		reservations.FieldReservationInstanceType: lo.FromPtr(reservation.InstanceType),
+		reservations.FieldReservationPrice:        price,
+		reservations.FieldReservationCount:        int(lo.FromPtr(reservation.Count)),
+		reservations.FieldReservationStartDate:    formatTime(reservation.StartDate),
+		reservations.FieldReservationEndDate:      formatTime(reservation.EndDate),
+		reservations.FieldReservationZoneId:       lo.FromPtr(reservation.ZoneId),
+		reservations.FieldReservationZoneName:     lo.FromPtr(reservation.ZoneName),
+	}
+}
+
+// suppressEquivalentRFC3339Diffs ignores differences of time zones, as dates are returned in UTC.
+func suppressEquivalentRFC3339Diffs(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, oldValue)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, newValue)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
@@ -0,0 +1,132 @@
+package castai
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/castai/terraform-provider-castai/castai/reservations"
+	"github.com/castai/terraform-provider-castai/castai/sdk"
+	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
+)
+
+const reservationTestOrganizationId = "7a704518-8275-4721-a622-18f4ec13fc22"
+
+func TestReservationResourceCreate(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{api: &sdk.ClientWithResponses{ClientInterface: mockClient}}
+
+	reservationId := "f3b2a1c0-9d8e-4f7a-b6c5-d4e3f2a1b0c9"
+	mockClient.EXPECT().
+		InventoryAPIAddReservation(gomock.Any(), reservationTestOrganizationId, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, req sdk.InventoryAPIAddReservationJSONRequestBody) (*http.Response, error) {
+			r.Equal("ri-1", *req.Name)
+			r.Equal("aws", *req.Provider)
+			r.Equal(int32(2), *req.Count)
+			r.Equal("0.05", *req.Price)
+			r.Equal("2023-12-31T22:00:00Z", req.StartDate.UTC().Format(time.RFC3339))
+			r.Nil(req.EndDate)
+			return jsonResponse(200, `{"reservation": {"reservationId": "f3b2a1c0-9d8e-4f7a-b6c5-d4e3f2a1b0c9"}}`), nil
+		})
+	mockClient.EXPECT().
+		InventoryAPIGetReservations(gomock.Any(), reservationTestOrganizationId).
+		Return(jsonResponse(200, `{"reservations": [
+			{"reservationId": "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", "name": "other"},
+			{"reservationId": "f3b2a1c0-9d8e-4f7a-b6c5-d4e3f2a1b0c9", "name": "ri-1", "provider": "aws", "region": "us-east-1",
+			 "instanceType": "m5.large", "price": "0.05", "count": 2, "startDate": "2023-12-31T22:00:00Z", "endDate": null}
+		]}`), nil)
+
+	resource := resourceReservation()
+	data := schema.TestResourceDataRaw(t, resource.Schema, map[string]any{
+		reservations.FieldReservationsOrganizationId: reservationTestOrganizationId,
+		reservations.FieldReservationName:            "ri-1",
+		reservations.FieldReservationCloudProvider:   "aws",
+		reservations.FieldReservationRegion:          "us-east-1",
+		reservations.FieldReservationInstanceType:    "m5.large",
+		reservations.FieldReservationPrice:           0.05,
+		reservations.FieldReservationInstanceCount:   2,
+		reservations.FieldReservationStartDate:       "2024-01-01T00:00:00+02:00",
+	})
+
+	result := resource.CreateContext(context.Background(), data, provider)
+	r.Nil(result)
+	r.Equal(reservationId, data.Id())
+	r.Equal("2023-12-31T22:00:00Z", data.Get(reservations.FieldReservationStartDate))
+	r.Equal(2, data.Get(reservations.FieldReservationInstanceCount))
+	r.Equal("aws", data.Get(reservations.FieldReservationCloudProvider))
+	r.Equal(0.05, data.Get(reservations.FieldReservationPrice))
+}
+
+func TestReservationResourceRead_notFound(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{api: &sdk.ClientWithResponses{ClientInterface: mockClient}}
+
+	mockClient.EXPECT().
+		InventoryAPIGetReservations(gomock.Any(), reservationTestOrganizationId).
+		Return(jsonResponse(200, `{"reservations": []}`), nil)
+
+	resource := resourceReservation()
+	val := cty.ObjectVal(map[string]cty.Value{
+		reservations.FieldReservationsOrganizationId: cty.StringVal(reservationTestOrganizationId),
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+	state.ID = "f3b2a1c0-9d8e-4f7a-b6c5-d4e3f2a1b0c9"
+	data := resource.Data(state)
+
+	result := resource.ReadContext(context.Background(), data, provider)
+	r.Nil(result)
+	r.Empty(data.Id())
+}
+
+func TestReservationResourceDelete(t *testing.T) {
+	r := require.New(t)
+	mockClient := mock_sdk.NewMockClientInterface(gomock.NewController(t))
+	provider := &ProviderConfig{api: &sdk.ClientWithResponses{ClientInterface: mockClient}}
+
+	reservationId := "f3b2a1c0-9d8e-4f7a-b6c5-d4e3f2a1b0c9"
+	mockClient.EXPECT().
+		InventoryAPIDeleteReservation(gomock.Any(), reservationTestOrganizationId, reservationId).
+		Return(jsonResponse(200, `{}`), nil)
+
+	resource := resourceReservation()
+	val := cty.ObjectVal(map[string]cty.Value{
+		reservations.FieldReservationsOrganizationId: cty.StringVal(reservationTestOrganizationId),
+	})
+	state := terraform.NewInstanceStateShimmedFromValue(val, 0)
+	state.ID = reservationId
+	data := resource.Data(state)
+
+	result := resource.DeleteContext(context.Background(), data, provider)
+	r.Nil(result)
+}
+
+func TestReservationStateImporter(t *testing.T) {
+	r := require.New(t)
+
+	data := resourceReservation().Data(&terraform.InstanceState{ID: reservationTestOrganizationId + "/f3b2a1c0-9d8e-4f7a-b6c5-d4e3f2a1b0c9"})
+	result, err := reservationStateImporter(context.Background(), data, nil)
+	r.NoError(err)
+	r.Len(result, 1)
+	r.Equal("f3b2a1c0-9d8e-4f7a-b6c5-d4e3f2a1b0c9", result[0].Id())
+	r.Equal(reservationTestOrganizationId, result[0].Get(reservations.FieldReservationsOrganizationId))
+
+	_, err = reservationStateImporter(context.Background(), resourceReservation().Data(&terraform.InstanceState{ID: "f3b2a1c0"}), nil)
+	r.ErrorContains(err, "expected import id in format <organization_id>/<reservation_id>")
+}
+
+func TestSuppressEquivalentRFC3339Diffs(t *testing.T) {
+	r := require.New(t)
+
+	r.True(suppressEquivalentRFC3339Diffs("", "2023-12-31T22:00:00Z", "2024-01-01T00:00:00+02:00", nil))
+	r.False(suppressEquivalentRFC3339Diffs("", "2023-12-31T22:00:00Z", "2024-01-01T00:00:00Z", nil))
+	r.False(suppressEquivalentRFC3339Diffs("", "", "2024-01-01T00:00:00Z", nil))
+}
@@ -36,9 +36,27 @@ func resourceReservations() *schema.Resource {
 		CustomizeDiff: reservationsDiff,
 		Schema: map[string]*schema.Schema{
 			reservations.FieldReservationsCSV: {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "csv file containing reservations",
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{reservations.FieldReservationsCSV, reservations.FieldReservation},
+				Description:  "csv file containing reservations",
+			},
+			reservations.FieldReservationsCSVFormat: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          reservations.CSVFormatCastAI,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(reservations.CSVFormats, false)),
+				Description: fmt.Sprintf("format of `reservations_csv`, either CAST AI columns or an export of the cloud provider. Supported values: %s",
+					strings.Join(reservations.CSVFormats, ", ")),
+			},
+			reservations.FieldReservation: {
+				Type:         schema.TypeList,
+				Optional:     true,
+				AtLeastOneOf: []string{reservations.FieldReservationsCSV, reservations.FieldReservation},
+				Elem: &schema.Resource{
+					Schema: reservationFields(),
+				},
+				Description: "reservation entry, merged with the ones from `reservations_csv`",
 			},
 			reservations.FieldReservationsOrganizationId: {
 				Type:        schema.TypeString,
@@ -110,9 +128,82 @@ func resourceReservations() *schema.Resource {
 	}
 }
 
+// reservationFields are attributes of a single reservation, shared by `reservation` blocks and castai_reservation resource.
+func reservationFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		reservations.FieldReservationName: {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+			Description:      "unique reservation name in region for specific instance type",
+		},
+		reservations.FieldReservationProvider: {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"aws", "gcp", "azure"}, false)),
+			Description:      "reservation cloud provider (gcp, aws, azure)",
+		},
+		reservations.FieldReservationRegion: {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+			Description:      "reservation region",
+		},
+		reservations.FieldReservationInstanceType: {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+			Description:      "reserved instance type",
+		},
+		reservations.FieldReservationPrice: {
+			Type:             schema.TypeFloat,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.FloatAtLeast(0)),
+			Description:      "reservation price",
+		},
+		reservations.FieldReservationCount: {
+			Type:             schema.TypeInt,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			Description:      "amount of reserved instances",
+		},
+		reservations.FieldReservationStartDate: {
+			Type:             schema.TypeString,
+			DiffSuppressFunc: suppressEquivalentRFC3339Diffs,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+			Description:      "start date of reservation",
+		},
+		reservations.FieldReservationEndDate: {
+			Type:             schema.TypeString,
+			DiffSuppressFunc: suppressEquivalentRFC3339Diffs,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+			Description:      "end date of reservation",
+		},
+		reservations.FieldReservationZoneId: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "reservation zone id",
+		},
+		reservations.FieldReservationZoneName: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "reservation zone name",
+		},
+	}
+}
+
 func reservationsDiff(_ context.Context, diff *schema.ResourceDiff, _ any) error {
-	reservationsCsv, _ := diff.GetOk(reservations.FieldReservationsCSV)
-	reservationResources, err := mapReservationsCsvToReservationResources(reservationsCsv.(string))
+	if !diff.NewValueKnown(reservations.FieldReservationsCSV) || !diff.NewValueKnown(reservations.FieldReservation) {
+		return diff.SetNewComputed(reservations.FieldReservations)
+	}
+
+	reservationResources, err := mapConfigToReservationResources(
+		diff.Get(reservations.FieldReservationsCSVFormat).(string),
+		diff.Get(reservations.FieldReservationsCSV).(string),
+		diff.Get(reservations.FieldReservation).([]any),
+	)
 	if err != nil {
 		return err
 	}
@@ -188,8 +279,11 @@ func resourceCastaiReservationsUpsert(ctx context.Context, data *schema.Resource
 		return diag.FromErr(err)
 	}
 
-	reservationsCsv, _ := data.GetOk(reservations.FieldReservationsCSV)
-	reservationResources, err := mapReservationsCsvToReservationResources(reservationsCsv.(string))
+	reservationResources, err := mapConfigToReservationResources(
+		data.Get(reservations.FieldReservationsCSVFormat).(string),
+		data.Get(reservations.FieldReservationsCSV).(string),
+		data.Get(reservations.FieldReservation).([]any),
+	)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -233,14 +327,42 @@ func populateReservationsResourceData(ctx context.Context, d *schema.ResourceDat
 	return nil
 }
 
-func mapReservationsCsvToReservationResources(reservationsCsv string) ([]*reservations.ReservationResource, error) {
+// mapConfigToReservationResources merges reservations given in CSV with the ones given in `reservation` blocks.
+func mapConfigToReservationResources(reservationsCsvFormat, reservationsCsv string, reservationBlocks []any) ([]*reservations.ReservationResource, error) {
+	result := make([]*reservations.ReservationResource, 0, len(reservationBlocks))
+	if reservationsCsv != "" {
+		csvResources, err := mapReservationsCsvToReservationResources(reservationsCsvFormat, reservationsCsv)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, csvResources...)
+	}
+
+	for _, block := range reservationBlocks {
+		if block == nil {
+			continue
+		}
+		result = append(result, reservations.MapReservationBlockToReservationResource(block.(map[string]any)))
+	}
+
+	// Reservations given in CSV only are passed as is, like before blocks were supported.
+	if len(reservationBlocks) > 0 {
+		if err := reservations.ValidateUniqueReservations(result); err != nil {
+			return nil, fmt.Errorf("validating reservations: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+func mapReservationsCsvToReservationResources(reservationsCsvFormat, reservationsCsv string) ([]*reservations.ReservationResource, error) {
 	csvReader := csv.NewReader(strings.NewReader(reservationsCsv))
 	csvRecords, err := csvReader.ReadAll()
 	if err != nil {
 		return nil, fmt.Errorf("parsing reservations csv: %w", err)
 	}
 
-	result, err := reservations.MapCsvRecordsToReservationResources(csvRecords)
+	result, err := reservations.MapCsvRecordsInFormatToReservationResources(reservationsCsvFormat, csvRecords)
 	if err != nil {
 		return nil, fmt.Errorf("parsing reservations csv: %w", err)
 	}
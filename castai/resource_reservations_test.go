@@ -12,8 +12,10 @@ import (
 	"github.com/castai/terraform-provider-castai/castai/sdk"
 	mock_sdk "github.com/castai/terraform-provider-castai/castai/sdk/mock"
 	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 )
@@ -235,3 +237,95 @@ func Test_getOrganizationId(t *testing.T) {
 		})
 	}
 }
+
+func Test_mapConfigToReservationResources(t *testing.T) {
+	reservationsCsv := `name,provider,region,instance_type,price,count,start_date,end_date,zone_id,zone_name
+reservation1,aws,us-east-1,c5n.large,,1,2020-01-01T00:00:00Z,2050-01-01T00:00:00Z,,
+`
+	block := func(name string) map[string]any {
+		return map[string]any{
+			reservations.FieldReservationName:         name,
+			reservations.FieldReservationProvider:     "aws",
+			reservations.FieldReservationRegion:       "us-east-1",
+			reservations.FieldReservationInstanceType: "c5n.large",
+			reservations.FieldReservationCount:        2,
+			reservations.FieldReservationStartDate:    "2020-01-01T00:00:00Z",
+		}
+	}
+
+	t.Run("should merge csv and block reservations", func(t *testing.T) {
+		r := require.New(t)
+
+		got, err := mapConfigToReservationResources(reservations.CSVFormatCastAI, reservationsCsv, []any{block("reservation2")})
+		r.NoError(err)
+		r.Len(got, 2)
+		r.Equal("reservation1", *(*got[0])[reservations.FieldReservationName])
+		r.Equal("reservation2", *(*got[1])[reservations.FieldReservationName])
+		r.Equal("2", *(*got[1])[reservations.FieldReservationCount])
+	})
+
+	t.Run("should accept blocks only", func(t *testing.T) {
+		r := require.New(t)
+
+		got, err := mapConfigToReservationResources(reservations.CSVFormatCastAI, "", []any{block("reservation1")})
+		r.NoError(err)
+		r.Len(got, 1)
+	})
+
+	t.Run("should map csv in cloud provider format", func(t *testing.T) {
+		r := require.New(t)
+
+		awsCsv := `Reserved Instance ID,Instance Type,Availability Zone,Instance Count,Start,Expires,State
+ri-1,m5.large,us-east-1a,2,2024-01-01T00:00:00.000Z,2025-01-01T00:00:00.000Z,active
+`
+		got, err := mapConfigToReservationResources(reservations.CSVFormatAWSRI, awsCsv, []any{block("reservation2")})
+		r.NoError(err)
+		r.Len(got, 2)
+		r.Equal("us-east-1", *(*got[0])[reservations.FieldReservationRegion])
+		r.Equal("aws", *(*got[0])[reservations.FieldReservationProvider])
+	})
+
+	t.Run("should accept duplicates in csv only", func(t *testing.T) {
+		r := require.New(t)
+
+		got, err := mapConfigToReservationResources(reservations.CSVFormatCastAI, reservationsCsv+"reservation1,aws,us-east-1,c5n.large,,2,2020-01-01T00:00:00Z,2050-01-01T00:00:00Z,,\n", nil)
+		r.NoError(err)
+		r.Len(got, 2)
+	})
+
+	t.Run("should return an error for duplicates across csv and blocks", func(t *testing.T) {
+		r := require.New(t)
+
+		_, err := mapConfigToReservationResources(reservations.CSVFormatCastAI, reservationsCsv, []any{block("reservation1")})
+		r.Error(err)
+		r.Contains(err.Error(), `duplicate reservation "reservation1"`)
+	})
+}
+
+func TestReservations_Diff(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	resource := resourceReservations()
+
+	val := cty.ObjectVal(map[string]cty.Value{
+		reservations.FieldReservation: cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				reservations.FieldReservationName:         cty.StringVal("reservation1"),
+				reservations.FieldReservationProvider:     cty.StringVal("aws"),
+				reservations.FieldReservationRegion:       cty.StringVal("us-east-1"),
+				reservations.FieldReservationInstanceType: cty.StringVal("c5n.large"),
+				reservations.FieldReservationPrice:        cty.NumberFloatVal(0.5),
+				reservations.FieldReservationCount:        cty.NumberIntVal(2),
+				reservations.FieldReservationStartDate:    cty.StringVal("2020-01-01T00:00:00Z"),
+			}),
+		}),
+	})
+	state := &terraform.InstanceState{RawConfig: val}
+	config := terraform.NewResourceConfigShimmed(val, resource.CoreConfigSchema())
+
+	diff, err := resource.Diff(ctx, state, config, nil)
+	r.NoError(err)
+	r.Equal("1", diff.Attributes["reservations.#"].New)
+	r.Equal("2", diff.Attributes["reservations.0.count"].New)
+	r.Equal("0.5", diff.Attributes["reservations.0.price"].New)
+}
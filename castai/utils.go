@@ -120,3 +120,23 @@ func normalizeJSON(bytes []byte) ([]byte, error) {
 	}
 	return json.Marshal(output)
 }
+
+// toComputedSchema converts resource schema attributes to read-only ones, so they can be reused in data sources.
+func toComputedSchema(in map[string]*schema.Schema) map[string]*schema.Schema {
+	out := make(map[string]*schema.Schema, len(in))
+	for k, v := range in {
+		s := &schema.Schema{
+			Type:        v.Type,
+			Computed:    true,
+			Description: v.Description,
+		}
+		switch elem := v.Elem.(type) {
+		case *schema.Resource:
+			s.Elem = &schema.Resource{Schema: toComputedSchema(elem.Schema)}
+		case *schema.Schema:
+			s.Elem = &schema.Schema{Type: elem.Type}
+		}
+		out[k] = s
+	}
+	return out
+}
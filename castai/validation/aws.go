@@ -0,0 +1,54 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// awsPartitions are partitions ARNs can be issued in, including GovCloud, China and isolated regions.
+var awsPartitions = []string{"aws", "aws-cn", "aws-us-gov", "aws-iso", "aws-iso-b", "aws-iso-e", "aws-iso-f"}
+
+var (
+	awsSubnetIDRegexp        = regexp.MustCompile(`^subnet-[0-9a-f]+$`)
+	awsSecurityGroupIDRegexp = regexp.MustCompile(`^sg-[0-9a-f]+$`)
+	awsAccountIDRegexp       = regexp.MustCompile(`^[0-9]{12}$`)
+)
+
+func ValidAWSSubnetID() schema.SchemaValidateDiagFunc {
+	return validFormat("AWS subnet ID", "subnet-<id>", awsSubnetIDRegexp.MatchString)
+}
+
+func ValidAWSSecurityGroupID() schema.SchemaValidateDiagFunc {
+	return validFormat("AWS security group ID", "sg-<id>", awsSecurityGroupIDRegexp.MatchString)
+}
+
+func ValidAWSAccountID() schema.SchemaValidateDiagFunc {
+	return validFormat("AWS account ID", "12 digits", awsAccountIDRegexp.MatchString)
+}
+
+// ValidARN validates ARN of the given AWS service in any partition. When resource types are provided,
+// resource part of the ARN has to be of one of them, e.g. "role" for arn:aws:iam::123456789012:role/name.
+func ValidARN(service string, resourceTypes ...string) schema.SchemaValidateDiagFunc {
+	resource := `.+`
+	format := fmt.Sprintf("arn:<partition>:%s:<region>:<account>:<resource>", service)
+	if len(resourceTypes) > 0 {
+		quoted := make([]string, len(resourceTypes))
+		for i, t := range resourceTypes {
+			quoted[i] = regexp.QuoteMeta(t)
+		}
+		resource = `(` + strings.Join(quoted, "|") + `)[/:].+`
+		format = fmt.Sprintf("arn:<partition>:%s:<region>:<account>:%s/<name>", service, strings.Join(resourceTypes, "|"))
+	}
+
+	re := regexp.MustCompile(`^arn:(` + strings.Join(awsPartitions, "|") + `):` + regexp.QuoteMeta(service) +
+		`:([a-z]{2}(-[a-z]+)+-[0-9]+)?:([0-9]{12})?:` + resource + `$`)
+	return validFormat(fmt.Sprintf("AWS %s ARN", strings.ToUpper(service)), format, re.MatchString)
+}
+
+// ValidKMSKeyARN validates ARN of KMS key or alias in any partition.
+func ValidKMSKeyARN() schema.SchemaValidateDiagFunc {
+	return ValidARN("kms", "key", "alias")
+}
@@ -0,0 +1,47 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const azureSubnetResourceType = "Microsoft.Network/virtualNetworks/subnets"
+
+var (
+	azureSubnetIDRegexp          = azureResourceIDRegexp(azureSubnetResourceType)
+	azureResourceGroupNameRegexp = regexp.MustCompile(`^[-\w.()]{0,89}[-\w()]$`)
+)
+
+// ValidAzureResourceID validates ID of Azure resource of the given type, e.g. "Microsoft.Network/virtualNetworks/subnets"
+// for /subscriptions/<subscription>/resourceGroups/<group>/providers/Microsoft.Network/virtualNetworks/<network>/subnets/<name>.
+func ValidAzureResourceID(resourceType string) schema.SchemaValidateDiagFunc {
+	namespace, types, _ := strings.Cut(resourceType, "/")
+	format := "/subscriptions/<subscription>/resourceGroups/<group>/providers/" + namespace
+	for _, t := range strings.Split(types, "/") {
+		format += fmt.Sprintf("/%s/<name>", t)
+	}
+	return validFormat(fmt.Sprintf("Azure %s resource ID", resourceType), format, azureResourceIDRegexp(resourceType).MatchString)
+}
+
+func ValidAzureSubnetID() schema.SchemaValidateDiagFunc {
+	return ValidAzureResourceID(azureSubnetResourceType)
+}
+
+func ValidAzureResourceGroupName() schema.SchemaValidateDiagFunc {
+	return validFormat("Azure resource group name",
+		"up to 90 alphanumerics, underscores, parentheses, hyphens and periods, not ending with a period",
+		azureResourceGroupNameRegexp.MatchString)
+}
+
+// azureResourceIDRegexp matches resource IDs of the given type. Azure resource IDs are case-insensitive.
+func azureResourceIDRegexp(resourceType string) *regexp.Regexp {
+	namespace, types, _ := strings.Cut(resourceType, "/")
+	pattern := `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/` + regexp.QuoteMeta(namespace)
+	for _, t := range strings.Split(types, "/") {
+		pattern += `/` + regexp.QuoteMeta(t) + `/[^/]+`
+	}
+	return regexp.MustCompile(pattern + `$`)
+}
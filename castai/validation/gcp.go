@@ -0,0 +1,26 @@
+package validation
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	gcpResourceNameRegexp = regexp.MustCompile(`^[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`)
+	gcpProjectIDRegexp    = regexp.MustCompile(`^([a-z0-9][-a-z0-9.]*[a-z0-9]:)?[a-z][-a-z0-9]{4,28}[a-z0-9]$`)
+	gcpSubnetworkRegexp   = regexp.MustCompile(`^(https://www\.googleapis\.com/compute/v1/)?projects/[^/]+/regions/[^/]+/subnetworks/[^/]+$`)
+)
+
+func ValidGCPNetworkTag() schema.SchemaValidateDiagFunc {
+	return validFormat("GCP network tag", "up to 63 lowercase letters, digits and hyphens, starting with a letter", gcpResourceNameRegexp.MatchString)
+}
+
+// ValidGCPProjectID validates project IDs, including domain-scoped ones, e.g. example.com:my-project.
+func ValidGCPProjectID() schema.SchemaValidateDiagFunc {
+	return validFormat("GCP project ID", "[<domain>:]<id>, id of 6 to 30 lowercase letters, digits and hyphens, starting with a letter", gcpProjectIDRegexp.MatchString)
+}
+
+func ValidGCPSubnetwork() schema.SchemaValidateDiagFunc {
+	return validFormat("GCP subnetwork", "projects/<project>/regions/<region>/subnetworks/<name>", gcpSubnetworkRegexp.MatchString)
+}
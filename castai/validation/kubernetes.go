@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const kubernetesLabelKeyFormat = "[<DNS subdomain prefix>/]<name>, name of up to 63 alphanumerics, '-', '_' or '.' starting and ending with alphanumeric"
+
+var (
+	kubernetesLabelNameRegexp  = regexp.MustCompile(`^([A-Za-z0-9][-A-Za-z0-9_.]{0,61})?[A-Za-z0-9]$`)
+	kubernetesLabelValueRegexp = regexp.MustCompile(`^(([A-Za-z0-9][-A-Za-z0-9_.]{0,61})?[A-Za-z0-9])?$`)
+	kubernetesDNSSubdomain     = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// ValidKubernetesLabelKey validates label keys, e.g. scheduling.cast.ai/spot.
+func ValidKubernetesLabelKey() schema.SchemaValidateDiagFunc {
+	return validFormat("Kubernetes label key", kubernetesLabelKeyFormat, isKubernetesLabelKey)
+}
+
+// ValidKubernetesLabels validates maps of labels, both keys and values.
+func ValidKubernetesLabels() schema.SchemaValidateDiagFunc {
+	return func(v interface{}, path cty.Path) diag.Diagnostics {
+		labels, ok := v.(map[string]interface{})
+		if !ok {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "wrong type",
+				Detail:   fmt.Sprintf("expected labels to be a map, got %T", v),
+			}}
+		}
+
+		var diags diag.Diagnostics
+		for key, value := range labels {
+			if !isKubernetesLabelKey(key) {
+				diags = append(diags, invalidValue(key, "Kubernetes label key", kubernetesLabelKeyFormat)...)
+			}
+			if s, _ := value.(string); !kubernetesLabelValueRegexp.MatchString(s) {
+				diags = append(diags, invalidValue(s, "Kubernetes label value",
+					"empty or up to 63 alphanumerics, '-', '_' or '.' starting and ending with alphanumeric")...)
+			}
+		}
+		return diags
+	}
+}
+
+func isKubernetesLabelKey(key string) bool {
+	prefix, name, hasPrefix := strings.Cut(key, "/")
+	if !hasPrefix {
+		return kubernetesLabelNameRegexp.MatchString(key)
+	}
+	return len(prefix) <= 253 && kubernetesDNSSubdomain.MatchString(prefix) && kubernetesLabelNameRegexp.MatchString(name)
+}
@@ -0,0 +1,41 @@
+package validation
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/crypto/ssh"
+)
+
+// ValidBase64SSHPublicKey validates base64 encoded SSH public key in authorized_keys format, e.g. base64 encoded content of id_ed25519.pub.
+func ValidBase64SSHPublicKey() schema.SchemaValidateDiagFunc {
+	return func(v interface{}, path cty.Path) diag.Diagnostics {
+		value, ok := v.(string)
+		if !ok {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "wrong type",
+				Detail:   fmt.Sprintf("expected SSH public key to be a string, got %T", v),
+			}}
+		}
+		if value == "" {
+			return nil
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return invalidValue(value, "base64 encoded SSH public key", "base64 encoded authorized_keys entry")
+		}
+		if _, _, _, _, err := ssh.ParseAuthorizedKey(decoded); err != nil {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "wrong value",
+				Detail:   fmt.Sprintf("decoded value is not a valid SSH public key in authorized_keys format, e.g. \"ssh-ed25519 AAAA... user@host\": %v", err),
+			}}
+		}
+		return nil
+	}
+}
@@ -2,6 +2,7 @@ package validation
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/go-cty/cty"
@@ -9,6 +10,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
 func ValidKeyPairFormat() schema.SchemaValidateDiagFunc {
 	return func(v interface{}, path cty.Path) diag.Diagnostics {
 		value := v.(string)
@@ -24,3 +27,70 @@ func ValidKeyPairFormat() schema.SchemaValidateDiagFunc {
 		return diags
 	}
 }
+
+// ValidUUID validates UUIDs, e.g. Azure subscription, tenant and application IDs.
+func ValidUUID() schema.SchemaValidateDiagFunc {
+	return validFormat("UUID", "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx", uuidRegexp.MatchString)
+}
+
+// ValidSubnetID validates subnet IDs of any supported cloud: AWS subnet ID, Azure subnet resource ID or GCP subnetwork.
+func ValidSubnetID() schema.SchemaValidateDiagFunc {
+	return validFormat("subnet ID",
+		"subnet-<id> on AWS, /subscriptions/<subscription>/resourceGroups/<group>/providers/Microsoft.Network/virtualNetworks/<network>/subnets/<name> on Azure "+
+			"or projects/<project>/regions/<region>/subnetworks/<name> on GCP",
+		func(value string) bool {
+			return awsSubnetIDRegexp.MatchString(value) || azureSubnetIDRegexp.MatchString(value) || gcpSubnetworkRegexp.MatchString(value)
+		})
+}
+
+// All runs validators in order and stops at the first one reporting an error.
+func All(validators ...schema.SchemaValidateDiagFunc) schema.SchemaValidateDiagFunc {
+	return func(v interface{}, path cty.Path) diag.Diagnostics {
+		var diags diag.Diagnostics
+		for _, validator := range validators {
+			diags = append(diags, validator(v, path)...)
+			if diags.HasError() {
+				break
+			}
+		}
+		return diags
+	}
+}
+
+// Warning reports errors of the validator as warnings. It's meant for attributes which accepted any value before,
+// so that existing configurations keep working.
+func Warning(validator schema.SchemaValidateDiagFunc) schema.SchemaValidateDiagFunc {
+	return func(v interface{}, path cty.Path) diag.Diagnostics {
+		diags := validator(v, path)
+		for i := range diags {
+			diags[i].Severity = diag.Warning
+		}
+		return diags
+	}
+}
+
+// validFormat returns validator reporting values not accepted by match, described by name and expected format.
+func validFormat(name, format string, match func(string) bool) schema.SchemaValidateDiagFunc {
+	return func(v interface{}, path cty.Path) diag.Diagnostics {
+		value, ok := v.(string)
+		if !ok {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "wrong type",
+				Detail:   fmt.Sprintf("expected %s to be a string, got %T", name, v),
+			}}
+		}
+		if match(value) {
+			return nil
+		}
+		return invalidValue(value, name, format)
+	}
+}
+
+func invalidValue(value, name, format string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  "wrong value",
+		Detail:   fmt.Sprintf("%q is not a valid %s, expected format: %s", value, name, format),
+	}}
+}
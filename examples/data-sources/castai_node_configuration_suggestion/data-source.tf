@@ -0,0 +1,14 @@
+data "castai_node_configuration_suggestion" "this" {
+  cluster_id = castai_eks_cluster.test.id
+}
+
+resource "castai_node_configuration" "default" {
+  name       = "default"
+  cluster_id = castai_eks_cluster.test.id
+  subnets    = data.castai_node_configuration_suggestion.this.subnets
+  eks {
+    instance_profile_arn = data.castai_node_configuration_suggestion.this.eks[0].instance_profile_arn
+    dns_cluster_ip       = data.castai_node_configuration_suggestion.this.eks[0].dns_cluster_ip
+    security_groups      = data.castai_node_configuration_suggestion.this.eks[0].security_groups
+  }
+}
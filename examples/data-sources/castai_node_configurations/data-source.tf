@@ -0,0 +1,14 @@
+data "castai_node_configurations" "default" {
+  cluster_id = castai_eks_cluster.test.id
+  default    = true
+}
+
+resource "castai_node_template" "spot" {
+  cluster_id       = castai_eks_cluster.test.id
+  name             = "spot"
+  configuration_id = data.castai_node_configurations.default.node_configurations[0].id
+
+  constraints {
+    spot = true
+  }
+}
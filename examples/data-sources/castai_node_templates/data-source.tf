@@ -0,0 +1,5 @@
+data "castai_node_templates" "team_a" {
+  cluster_id  = castai_eks_cluster.test.id
+  name_prefix = "team-a-"
+  is_enabled  = true
+}
@@ -0,0 +1,7 @@
+data "castai_rebalancing_jobs" "all" {
+  cluster_id = castai_eks_cluster.test.id
+}
+
+output "failed_rebalancing_jobs" {
+  value = [for job in data.castai_rebalancing_jobs.all.jobs : job.rebalancing_schedule_id if job.status == "JobStatusFailed"]
+}
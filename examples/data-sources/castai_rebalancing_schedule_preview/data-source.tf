@@ -0,0 +1,15 @@
+data "castai_rebalancing_schedule_preview" "spots" {
+  cluster_id = castai_eks_cluster.test.id
+
+  schedule {
+    cron = "CRON_TZ=America/New_York 0 12 * * ?"
+  }
+  launch_configuration {
+    node_ttl_seconds   = 3600
+    num_targeted_nodes = 3
+  }
+}
+
+output "next_rebalancing" {
+  value = data.castai_rebalancing_schedule_preview.spots.trigger_times[0].local
+}
@@ -0,0 +1,19 @@
+data "castai_rebalancing_time_zones" "all" {}
+
+locals {
+  time_zone = one([for tz in data.castai_rebalancing_time_zones.all.time_zones : tz.name if tz.name == "America/New_York"])
+}
+
+resource "castai_rebalancing_schedule" "spots" {
+  name = "rebalance spots at every 30th minute"
+  schedule {
+    cron = "CRON_TZ=${local.time_zone} */30 * * * *"
+  }
+  trigger_conditions {
+    savings_percentage = 20
+  }
+  launch_configuration {
+    node_ttl_seconds   = 300
+    num_targeted_nodes = 3
+  }
+}
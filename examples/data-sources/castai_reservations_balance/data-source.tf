@@ -0,0 +1,18 @@
+data "castai_reservations_balance" "current" {}
+
+check "reservations_utilization" {
+  assert {
+    condition = alltrue([
+      for reservation in data.castai_reservations_balance.current.reservations : reservation.remaining_count == 0
+    ])
+    error_message = "Some reserved instances are not used by any cluster."
+  }
+
+  assert {
+    condition = alltrue([
+      for reservation in data.castai_reservations_balance.current.reservations :
+      reservation.end_date == "" || timecmp(reservation.end_date, timeadd(plantimestamp(), "720h")) > 0
+    ])
+    error_message = "Some reservations expire within 30 days."
+  }
+}
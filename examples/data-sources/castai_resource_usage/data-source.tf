@@ -0,0 +1,7 @@
+data "castai_resource_usage" "cluster" {
+  cluster_id = castai_eks_cluster.test.id
+}
+
+output "cluster_vcpu" {
+  value = data.castai_resource_usage.cluster.vcpu
+}
@@ -0,0 +1,2 @@
+# Import default node template by specifying cluster ID.
+terraform import castai_default_node_template.default 105e6fa3-20b1-424e-v589-9a64d1eeabea
@@ -0,0 +1,13 @@
+resource "castai_default_node_template" "default" {
+  cluster_id = castai_eks_cluster.test.id
+
+  configuration_id = castai_node_configuration.default.id
+  should_taint     = false
+
+  constraints {
+    spot               = true
+    use_spot_fallbacks = true
+    min_cpu            = 2
+    max_cpu            = 16
+  }
+}
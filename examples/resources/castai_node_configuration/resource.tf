@@ -17,10 +17,17 @@ resource "castai_node_configuration" "default" {
     "insecure-registries"      = ["registry.com:5000"],
     "max-concurrent-downloads" = 10
   })
-  kubelet_config = jsonencode({
-    "registryBurst" : 20,
-    "registryPullQPS" : 10
-  })
+  kubelet {
+    max_pods = 58
+    eviction_hard = {
+      "memory.available" = "100Mi"
+      "nodefs.available" = "10%"
+    }
+    kube_reserved = {
+      cpu    = "100m"
+      memory = "512Mi"
+    }
+  }
   container_runtime = "dockerd"
   tags = {
     env = "development"
@@ -0,0 +1,10 @@
+resource "castai_rebalancing_jobs" "spots" {
+  rebalancing_schedule_id = castai_rebalancing_schedule.spots.id
+  enabled                 = !castai_rebalancing_schedule.spots.suspended
+
+  cluster_selector {
+    provider_types = ["eks"]
+    regions        = ["eu-central-1", "eu-west-1"]
+    name_regex     = "^prod-"
+  }
+}
@@ -12,19 +12,20 @@ resource "castai_rebalancing_schedule" "spots" {
     num_targeted_nodes       = 3
     rebalancing_min_nodes    = 2
     keep_drain_timeout_nodes = false
-    selector = jsonencode({
-      nodeSelectorTerms = [{
-        matchExpressions = [
-          {
-            key      = "scheduling.cast.ai/spot"
-            operator = "Exists"
-          }
-        ]
-      }]
-    })
+    node_selector {
+      match_expressions {
+        key      = "scheduling.cast.ai/spot"
+        operator = "Exists"
+      }
+    }
     execution_conditions {
       enabled                     = true
       achieved_savings_percentage = 10
     }
   }
+  # no rebalancing during the Black Friday freeze
+  blackout_windows {
+    start = "2025-11-28T00:00:00Z"
+    end   = "2025-12-02T00:00:00Z"
+  }
 }
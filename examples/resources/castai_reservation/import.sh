@@ -0,0 +1,2 @@
+# Import reservation by specifying organization ID and reservation ID.
+terraform import 'castai_reservation.m5_large' 5b046e29-e947-470f-9952-bfdf369ecca6/f3b2a1c0-9d8e-4f7a-b6c5-d4e3f2a1b0c9
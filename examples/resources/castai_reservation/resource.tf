@@ -0,0 +1,9 @@
+resource "castai_reservation" "m5_large" {
+  name           = "ri-0123456789abcdef0"
+  cloud_provider = "aws"
+  region         = "us-east-1"
+  instance_type  = "m5.large"
+  instance_count = 4
+  start_date     = "2024-01-01T00:00:00Z"
+  end_date       = "2027-01-01T00:00:00Z"
+}